@@ -0,0 +1,345 @@
+// Package cassette records the HTTP traffic between the client and the Lightstep API to
+// files ("cassettes") and replays it later, so acceptance tests can run without credentials.
+// Secrets in bodies are masked with client.RedactJSON before they are written, and the values
+// sent by the replayed requests are put back into the responses.
+//
+//	rec, err := cassette.New("testdata/cassettes/TestAccDashboard.json", cassette.ModeReplay)
+//	c := client.NewClient(key, org, url, client.WithTransport(rec.Transport(http.DefaultTransport)))
+//	...
+//	err = rec.Save() // only writes in ModeRecord
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+// Mode selects whether a Recorder talks to the API or to its cassette.
+type Mode string
+
+const (
+	// ModeRecord forwards requests to the API and records every interaction.
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from the cassette and never touches the network.
+	ModeReplay Mode = "replay"
+)
+
+// Cassette is the on-disk representation of a recording.
+type Cassette struct {
+	// Org is the organization the cassette was recorded against.
+	Org          string        `json:"org,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single request and the response the API returned to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. JSON bodies are stored in Body, anything else in Text.
+type Request struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// Response is a recorded response. JSON bodies are stored in Body, anything else in Text.
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+	Text       string            `json:"text,omitempty"`
+}
+
+// recordedHeaders are the only response headers written to cassettes.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// Recorder records or replays the interactions of a single cassette.
+type Recorder struct {
+	path    string
+	mode    Mode
+	matcher Matcher
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	// secrets are the values last sent for the attributes masked in the cassette, by name
+	secrets map[string]string
+}
+
+// Option customizes a Recorder.
+type Option func(*Recorder)
+
+// WithMatcher replaces DefaultMatcher.
+func WithMatcher(m Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the cassette must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, matcher: DefaultMatcher}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %v", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+		r.secrets = map[string]string{}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q, expected %q or %q", mode, ModeRecord, ModeReplay)
+	}
+	return r, nil
+}
+
+// Mode returns the mode the recorder was created with.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Org returns the organization the cassette was recorded against.
+func (r *Recorder) Org() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Org
+}
+
+// SetOrg stores the organization being recorded against.
+func (r *Recorder) SetOrg(org string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Org = org
+}
+
+// Transport returns a RoundTripper recording the traffic sent through next, or replaying it
+// without calling next at all.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if r.mode == ModeReplay {
+			return r.replay(req)
+		}
+		return r.record(req, next)
+	})
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recorded := Interaction{
+		Request:  Request{Method: req.Method, URL: req.URL.String()},
+		Response: Response{StatusCode: resp.StatusCode, Headers: headers},
+	}
+	// cassettes are committed, so secrets are masked like in the debug log
+	recorded.Request.Body, recorded.Request.Text = splitBody(client.RedactJSON(reqBody))
+	recorded.Response.Body, recorded.Response.Text = splitBody(client.RedactJSON(respBody))
+	r.cassette.Interactions = append(r.cassette.Interactions, recorded)
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	masked := client.RedactJSON(reqBody)
+	incoming := Request{Method: req.Method, URL: req.URL.String()}
+	incoming.Body, incoming.Text = splitBody(masked)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sent, sentMasked interface{}
+	if json.Unmarshal(reqBody, &sent) == nil && json.Unmarshal(masked, &sentMasked) == nil {
+		collectSecrets(sent, sentMasked, r.secrets)
+	}
+
+	// interactions are consumed in the order they were recorded, so the same request can
+	// observe a resource before and after it changed
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(incoming, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if len(body) == 0 {
+			body = []byte(interaction.Response.Text)
+		} else {
+			body = restoreSecrets(body, r.secrets)
+		}
+		resp := &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}
+		for name, value := range interaction.Response.Headers {
+			resp.Header.Set(name, value)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction matching %s %s", r.path, req.Method, req.URL)
+}
+
+// collectSecrets stores the values of sent that masked hides, by attribute name.
+func collectSecrets(sent interface{}, masked interface{}, secrets map[string]string) {
+	switch masked := masked.(type) {
+	case map[string]interface{}:
+		sent, _ := sent.(map[string]interface{})
+		for key, value := range masked {
+			if s, ok := sent[key].(string); ok && value == client.RedactedValue && s != client.RedactedValue {
+				secrets[key] = s
+				continue
+			}
+			collectSecrets(sent[key], value, secrets)
+		}
+	case []interface{}:
+		sent, _ := sent.([]interface{})
+		for i, value := range masked {
+			if i < len(sent) {
+				collectSecrets(sent[i], value, secrets)
+			}
+		}
+	}
+}
+
+// restoreSecrets puts the secrets last sent back into a recorded JSON body, so that replayed
+// reads return what the test configured rather than the masked value. A secret is found by
+// the name of its attribute, so a test sending different values for the same attribute gets
+// the last one.
+func restoreSecrets(body []byte, secrets map[string]string) []byte {
+	if len(secrets) == 0 {
+		return body
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+
+	var restore func(v interface{}) bool
+	restore = func(v interface{}) bool {
+		changed := false
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				if secret, ok := secrets[key]; ok && value == client.RedactedValue {
+					v[key] = secret
+					changed = true
+					continue
+				}
+				changed = restore(value) || changed
+			}
+		case []interface{}:
+			for _, value := range v {
+				changed = restore(value) || changed
+			}
+		}
+		return changed
+	}
+	if !restore(doc) {
+		return body
+	}
+	restored, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return restored
+}
+
+// readBody drains *body and replaces it with an equivalent reader.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// splitBody keeps JSON bodies readable in the cassette and returns anything else as text.
+func splitBody(body []byte) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, ""
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		return compact.Bytes(), ""
+	}
+	return nil, string(body)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,142 @@
+package cassette_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+	"github.com/lightstep/terraform-provider-lightstep/client/cassette"
+)
+
+func snoozeRule(start time.Time) client.SnoozeRule {
+	return client.SnoozeRule{
+		Title: "maintenance",
+		Schedule: client.Schedule{
+			OneTime: &client.OneTimeSchedule{
+				Timezone:      "UTC",
+				StartDateTime: start.Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+func TestRecorder_record_then_replay(t *testing.T) {
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		_, err := fmt.Fprintf(w, `{"data": {"id": "generated-%d", "attributes": {"title": "maintenance"}}}`, created)
+		require.NoError(t, err)
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassettes", "snooze.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	require.NoError(t, err)
+	rec.SetOrg("blars")
+
+	c := client.NewClient("secret-key", "blars", server.URL, client.WithTransport(rec.Transport(http.DefaultTransport)))
+	recorded, err := c.CreateSnoozeRule(context.Background(), "tacoman", snoozeRule(time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+	server.Close()
+
+	var saved cassette.Cassette
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, "blars", saved.Org)
+	require.Len(t, saved.Interactions, 1)
+	assert.NotContains(t, string(data), "secret-key")
+
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	require.NoError(t, err)
+	assert.Equal(t, "blars", replay.Org())
+
+	// a different host and start time still match, and the server is gone
+	c = client.NewClient("other-key", "blars", "https://api.example.com", client.WithTransport(replay.Transport(nil)), client.WithRetryMax(0))
+	replayed, err := c.CreateSnoozeRule(context.Background(), "tacoman", snoozeRule(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, recorded.ID, replayed.ID)
+
+	// each interaction is only replayed once
+	_, err = c.CreateSnoozeRule(context.Background(), "tacoman", snoozeRule(time.Now()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no unused interaction matching POST")
+}
+
+func TestRecorder_masks_secrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"data": {"id": "d1", "attributes": {"name": "on-call", "destination_type": "pagerduty", "integration_key": "pd-secret"}}}`))
+		require.NoError(t, err)
+	}))
+
+	destination := client.Destination{
+		Type: "destination",
+		Attributes: client.PagerdutyAttributes{
+			Name:            "on-call",
+			IntegrationKey:  "pd-secret",
+			DestinationType: "pagerduty",
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "pagerduty.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	require.NoError(t, err)
+	c := client.NewClient("secret-key", "blars", server.URL, client.WithTransport(rec.Transport(http.DefaultTransport)))
+	_, err = c.CreateDestination(context.Background(), "tacoman", destination)
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "pd-secret")
+	assert.Contains(t, string(data), `"integration_key": "***"`)
+
+	// the request still matches, and the response carries the key that was sent
+	replay, err := cassette.New(path, cassette.ModeReplay)
+	require.NoError(t, err)
+	c = client.NewClient("other-key", "blars", "https://api.example.com", client.WithTransport(replay.Transport(nil)), client.WithRetryMax(0))
+	replayed, err := c.CreateDestination(context.Background(), "tacoman", destination)
+	require.NoError(t, err)
+	assert.Equal(t, "pd-secret", replayed.Attributes.(client.PagerdutyAttributes).IntegrationKey)
+}
+
+func TestDefaultMatcher(t *testing.T) {
+	match := cassette.DefaultMatcher
+
+	assert.True(t, match(
+		cassette.Request{Method: "GET", URL: "http://localhost:1234/public/v0.2/org/projects/p/metric_dashboards/abc"},
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/projects/p/metric_dashboards/xyz"},
+	))
+	assert.False(t, match(
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/projects/p/metric_dashboards/abc"},
+		cassette.Request{Method: "DELETE", URL: "https://api.lightstep.com/public/v0.2/org/projects/p/metric_dashboards/abc"},
+	))
+	assert.False(t, match(
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/projects/p/metric_dashboards"},
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/projects/p/metric_alerts"},
+	))
+	assert.True(t, match(
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/role-binding?role-name=viewer&project=p"},
+		cassette.Request{Method: "GET", URL: "https://api.lightstep.com/public/v0.2/org/role-binding?project=p&role-name=viewer"},
+	))
+
+	assert.True(t, match(
+		cassette.Request{Method: "POST", URL: "/x", Body: json.RawMessage(`{"data": {"id": "1", "stream_id": "s1", "event_query_ids": ["a"], "name": "n"}}`)},
+		cassette.Request{Method: "POST", URL: "/x", Body: json.RawMessage(`{"data": {"name": "n", "id": "2", "stream_id": "s2", "event_query_ids": ["b"]}}`)},
+	))
+	assert.False(t, match(
+		cassette.Request{Method: "POST", URL: "/x", Body: json.RawMessage(`{"data": {"name": "n"}}`)},
+		cassette.Request{Method: "POST", URL: "/x", Body: json.RawMessage(`{"data": {"name": "m"}}`)},
+	))
+}
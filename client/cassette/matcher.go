@@ -0,0 +1,119 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Matcher reports whether an incoming request should be answered with a recorded interaction.
+type Matcher func(incoming Request, recorded Request) bool
+
+const (
+	idPlaceholder        = "{id}"
+	timestampPlaceholder = "{timestamp}"
+)
+
+var (
+	// objectPathPattern matches the ID segment of project scoped resource URLs,
+	// e.g. /projects/p/metric_dashboards/{id}
+	objectPathPattern = regexp.MustCompile(`(/projects/[^/]+/[^/]+/)[^/]+`)
+	// idKeyPattern matches attribute names holding generated IDs: id, stream_id, event_query_ids, ...
+	idKeyPattern = regexp.MustCompile(`(?i)(^|[_-])ids?$`)
+)
+
+// DefaultMatcher matches requests with the same method, path, query and body, ignoring
+// the scheme and host, generated IDs and timestamps, which differ from run to run.
+func DefaultMatcher(incoming Request, recorded Request) bool {
+	return incoming.Method == recorded.Method &&
+		NormalizeURL(incoming.URL) == NormalizeURL(recorded.URL) &&
+		NormalizeBody(incoming) == NormalizeBody(recorded)
+}
+
+// NormalizeURL strips the scheme and host of rawURL and replaces resource IDs and timestamps
+// in its path and query with placeholders.
+func NormalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	path := objectPathPattern.ReplaceAllString(u.EscapedPath(), "${1}"+idPlaceholder)
+
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			if isTimestamp(value) {
+				value = timestampPlaceholder
+			}
+			params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	if len(params) == 0 {
+		return path
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
+// NormalizeBody returns the body of req with generated IDs and timestamps replaced by
+// placeholders and object keys in a stable order.
+func NormalizeBody(req Request) string {
+	if len(req.Body) == 0 {
+		return req.Text
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(req.Body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return string(req.Body)
+	}
+
+	normalized, err := json.Marshal(normalizeValue("", doc))
+	if err != nil {
+		return string(req.Body)
+	}
+	return string(normalized)
+}
+
+func normalizeValue(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			v[k] = normalizeValue(k, value)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeValue(key, v[i])
+		}
+		return v
+	case string:
+		if v != "" && idKeyPattern.MatchString(key) {
+			return idPlaceholder
+		}
+		if isTimestamp(v) {
+			return timestampPlaceholder
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isTimestamp reports whether value is an RFC 3339 timestamp, with or without fractional seconds.
+func isTimestamp(value string) bool {
+	_, err := time.Parse(time.RFC3339Nano, value)
+	return err == nil
+}
@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -23,6 +25,9 @@ const (
 	DefaultRateLimitPerSecond = 2
 	DefaultTimeoutSeconds     = 60
 	DefaultUserAgent          = "terraform-provider-lightstep"
+	DefaultRetryMax           = 4
+	DefaultRetryWaitMin       = 1 * time.Second
+	DefaultRetryWaitMax       = 30 * time.Second
 )
 
 type Headers map[string]string
@@ -36,6 +41,8 @@ type APIResponseCarrier interface {
 type APIClientError struct {
 	Response *http.Response
 	Message  string
+	// Errors holds the decoded JSON:API "errors" array when the response body contained one
+	Errors []APIError
 }
 
 func (a APIClientError) Error() string {
@@ -68,17 +75,53 @@ type Client struct {
 	baseUrl     string
 	orgName     string
 	client      *retryablehttp.Client
-	rateLimiter *rate.Limiter
+	rateLimiter *adaptiveLimiter
 	contentType string
 	userAgent   string
+
+	// readOnly refuses requests that could change state, see WithReadOnly
+	readOnly bool
+
+	// idempotencySupported is set once the API echoes an Idempotency-Key back
+	idempotencySupported atomic.Bool
+
+	// roleBindingLocks serializes updates of a role binding, see ModifyRoleBinding
+	roleBindingLocks sync.Map
+}
+
+// Option customizes a Client built by NewClient or NewClientWithUserAgent.
+type Option func(*Client)
+
+// WithRetryMax sets how many times a request is retried after a 5xx or 429 response.
+func WithRetryMax(retryMax int) Option {
+	return func(c *Client) {
+		c.client.RetryMax = retryMax
+	}
+}
+
+// WithRateLimit overrides the number of requests per second otherwise read from LIGHTSTEP_API_RATE_LIMIT.
+func WithRateLimit(limit rate.Limit) Option {
+	return func(c *Client) {
+		c.rateLimiter.baseLimit = limit
+		c.rateLimiter.limiter.SetLimit(limit)
+	}
+}
+
+// WithRetryWait bounds the exponential backoff between retries. A Retry-After header
+// returned by the API takes precedence over these bounds.
+func WithRetryWait(min time.Duration, max time.Duration) Option {
+	return func(c *Client) {
+		c.client.RetryWaitMin = min
+		c.client.RetryWaitMax = max
+	}
 }
 
 // NewClient gets a client for the public API
-func NewClient(apiKey string, orgName string, baseUrl string) *Client {
-	return NewClientWithUserAgent(apiKey, orgName, baseUrl, fmt.Sprintf("%s/%s", DefaultUserAgent, version.ProviderVersion))
+func NewClient(apiKey string, orgName string, baseUrl string, opts ...Option) *Client {
+	return NewClientWithUserAgent(apiKey, orgName, baseUrl, fmt.Sprintf("%s/%s", DefaultUserAgent, version.ProviderVersion), opts...)
 }
 
-func NewClientWithUserAgent(apiKey string, orgName string, baseUrl string, userAgent string) *Client {
+func NewClientWithUserAgent(apiKey string, orgName string, baseUrl string, userAgent string, opts ...Option) *Client {
 	fullBaseUrl := fmt.Sprintf("%s/public/v0.2/%v", baseUrl, orgName)
 
 	rateLimitStr := os.Getenv("LIGHTSTEP_API_RATE_LIMIT")
@@ -87,49 +130,110 @@ func NewClientWithUserAgent(apiKey string, orgName string, baseUrl string, userA
 		rateLimit = DefaultRateLimitPerSecond
 	}
 
+	limiter := newAdaptiveLimiter(rate.Limit(rateLimit))
+
 	// Default client retries 5xx and 429 errors.
 	newClient := retryablehttp.NewClient()
 	newClient.HTTPClient.Timeout = DefaultTimeoutSeconds * time.Second
+	newClient.RetryMax = DefaultRetryMax
+	newClient.RetryWaitMin = DefaultRetryWaitMin
+	newClient.RetryWaitMax = DefaultRetryWaitMax
+	newClient.Backoff = backoff
+	// Every attempt, including retries, feeds the rate-limit headers back into the shared limiter.
+	newClient.ResponseLogHook = func(_ retryablehttp.Logger, resp *http.Response) {
+		limiter.observe(resp)
+	}
+	newClient.RequestLogHook = countAttempt
+	// Return the last response once retries are exhausted, so that its status and body
+	// reach newAPIClientError instead of a generic "giving up" error.
+	newClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
 
-	return &Client{
+	c := &Client{
 		apiKey:      apiKey,
 		orgName:     orgName,
 		baseUrl:     fullBaseUrl,
 		userAgent:   userAgent,
-		rateLimiter: rate.NewLimiter(rate.Limit(rateLimit), 1),
+		rateLimiter: limiter,
 		client:      newClient,
 		contentType: "application/vnd.api+json",
 	}
+	newClient.CheckRetry = c.checkRetry
+	// Retries wait for the limiter too, which a 429 has just tightened.
+	newClient.PrepareRetry = func(req *http.Request) error {
+		return c.waitForRateLimit(req.Context())
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // CallAPI calls the given API and unmarshals the result to into result.
+// POST requests carry an idempotency key that stays the same across retries.
+// In read-only mode, requests that could change state fail with a *ReadOnlyError.
 func (c *Client) CallAPI(ctx context.Context, httpMethod string, suffix string, data interface{}, result interface{}) error {
+	if err := c.checkReadOnly(httpMethod, suffix); err != nil {
+		return err
+	}
+
+	headers := c.defaultHeaders()
+	if httpMethod == "POST" {
+		if key := newIdempotencyKey(); key != "" {
+			headers[IdempotencyKeyHeader] = key
+		}
+	}
+
 	return callAPI(
 		ctx,
 		c,
 		fmt.Sprintf("%v/%v", c.baseUrl, suffix),
 		httpMethod,
-		Headers{
-			"Authorization":   fmt.Sprintf("bearer %v", c.apiKey),
-			"User-Agent":      c.userAgent,
-			"X-Lightstep-Org": c.orgName,
-			"Content-Type":    c.contentType,
-			"Accept":          c.contentType,
-		},
+		headers,
 		data,
 		result,
 	)
 }
 
+// defaultHeaders returns the headers sent along with every public API request.
+func (c *Client) defaultHeaders() Headers {
+	return Headers{
+		"Authorization":   fmt.Sprintf("bearer %v", c.apiKey),
+		"User-Agent":      c.userAgent,
+		"X-Lightstep-Org": c.orgName,
+		"Content-Type":    c.contentType,
+		"Accept":          c.contentType,
+	}
+}
+
+// waitForRateLimit blocks until the rate limiter allows another attempt, unless rate
+// limiting is disabled with LS_DISABLE_RATE_LIMIT.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	if len(os.Getenv("LS_DISABLE_RATE_LIMIT")) != 0 {
+		return nil
+	}
+	return c.rateLimiter.Wait(ctx)
+}
+
 func executeAPIRequest(ctx context.Context, c *Client, req *retryablehttp.Request, result interface{}) error {
-	if len(os.Getenv("LS_DISABLE_RATE_LIMIT")) == 0 {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return err
-		}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return err
 	}
 
+	debug := debugLogEnabled()
+	if debug {
+		reqBody, _ := req.BodyBytes()
+		logAPIRequest(req.Method, req.URL.String(), req.Header, reqBody)
+	}
+
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close() // nolint: errcheck
+		}
+		if debug {
+			log.Printf("[DEBUG] Lightstep API request failed: %s %s after %v: %v", req.Method, req.URL, time.Since(start).Round(time.Millisecond), err)
+		}
 		return APIClientError{
 			Response: resp,
 			Message:  fmt.Sprintf("%v failed: %v: %v", req.Method, req.URL, err),
@@ -142,11 +246,12 @@ func executeAPIRequest(ctx context.Context, c *Client, req *retryablehttp.Reques
 		return err
 	}
 
+	if debug {
+		logAPIResponse(req.Method, req.URL.String(), resp, time.Since(start), body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return APIClientError{
-			Response: resp,
-			Message:  fmt.Sprintf("status %d (%s): %q", resp.StatusCode, resp.Status, string(body)),
-		}
+		return newAPIClientError(resp, body)
 	}
 
 	if result != nil {
@@ -204,7 +309,10 @@ func callAPI(
 	headers Headers,
 	data interface{},
 	result interface{},
-) error {
+) (err error) {
+	ctx, span := startAPISpan(ctx, httpMethod, url)
+	defer func() { endAPISpan(ctx, span, err) }()
+
 	req, err := createJSONRequest(
 		ctx,
 		httpMethod,
@@ -1,9 +1,14 @@
 package client
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew_public(t *testing.T) {
@@ -11,3 +16,71 @@ func TestNew_public(t *testing.T) {
 	c := NewClient("api-key", "org-name", "https://api.lightstep.com")
 	assert.Equal(t, "https://api.lightstep.com/public/v0.2/org-name", c.baseUrl)
 }
+
+func Test_APIClientError_decodes_jsonapi_errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(`{"errors": [
+			{"status": "400", "code": "invalid_query", "title": "Invalid query", "detail": "unknown metric \"foo\"", "source": {"pointer": "/data/attributes/charts/3/metric-queries/0/query-string"}},
+			"name is required"
+		]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	_, err := c.GetUnifiedDashboard(context.Background(), "tacoman", "hi")
+	require.Error(t, err)
+
+	apiErr, ok := err.(APIClientError)
+	require.True(t, ok)
+	assert.Equal(t, []APIError{
+		{
+			Status: "400",
+			Code:   "invalid_query",
+			Title:  "Invalid query",
+			Detail: `unknown metric "foo"`,
+			Source: &APIErrorSource{Pointer: "/data/attributes/charts/3/metric-queries/0/query-string"},
+		},
+		{Detail: "name is required"},
+	}, apiErr.Errors)
+	assert.Equal(t,
+		`status 400 (400 Bad Request): Invalid query: unknown metric "foo" (at /data/attributes/charts/3/metric-queries/0/query-string); name is required`,
+		err.Error(),
+	)
+	assert.True(t, IsValidation(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func Test_APIClientError_keeps_raw_body_without_jsonapi_errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte("page not found"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	_, err := c.GetUnifiedDashboard(context.Background(), "tacoman", "hi")
+	require.Error(t, err)
+
+	assert.Equal(t, `status 404 (404 Not Found): "page not found"`, err.Error())
+	assert.Empty(t, err.(APIClientError).Errors)
+	assert.True(t, IsNotFound(err))
+}
+
+func Test_IsStatusHelpers(t *testing.T) {
+	withStatus := func(code int) error {
+		return APIClientError{Response: &http.Response{StatusCode: code}}
+	}
+
+	assert.True(t, IsNotFound(withStatus(http.StatusNotFound)))
+	assert.True(t, IsConflict(withStatus(http.StatusConflict)))
+	assert.True(t, IsValidation(withStatus(http.StatusUnprocessableEntity)))
+	assert.True(t, IsRateLimited(withStatus(http.StatusTooManyRequests)))
+	assert.True(t, IsNotFound(fmt.Errorf("wrapped: %w", withStatus(http.StatusNotFound))))
+
+	assert.False(t, IsNotFound(nil))
+	assert.False(t, IsNotFound(fmt.Errorf("not an API error")))
+	assert.False(t, IsConflict(APIClientError{}))
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultProfile is the profile used when none is selected explicitly.
+	DefaultProfile = "default"
+	// apiKeyCommandTimeout bounds how long a credential helper may run.
+	apiKeyCommandTimeout = time.Minute
+)
+
+// CredentialsConfig lists the sources the API key, organization and API URL are resolved from.
+type CredentialsConfig struct {
+	// APIKey, Org and APIURL are explicit values. They take precedence over any profile.
+	APIKey string
+	Org    string
+	APIURL string
+	// KeepAPIURL stops a profile from filling in an empty APIURL, because the caller
+	// derives the URL from another explicit setting.
+	KeepAPIURL bool
+	// APIKeyCommand is run through the shell; the key is read from its standard output.
+	APIKeyCommand string
+	// APIKeyEnvVar names the environment variable holding the key.
+	APIKeyEnvVar string
+	// Profile selects a section of ConfigFile. When empty, the "default" profile is used if present.
+	Profile string
+	// ConfigFile is the path of the profiles file, DefaultConfigFile() when empty.
+	ConfigFile string
+}
+
+// Credentials are the resolved values. Fields no source provided are left empty.
+type Credentials struct {
+	APIKey string
+	Org    string
+	APIURL string
+}
+
+// Profile is a section of the profiles file:
+//
+//	[default]
+//	organization    = my-org
+//	api_url         = https://api.lightstep.com
+//	api_key_command = vault kv get -field=api_key secret/lightstep
+type Profile struct {
+	Org           string
+	APIURL        string
+	APIKey        string
+	APIKeyCommand string
+}
+
+// DefaultConfigFile returns the path of the profiles file, ~/.lightstep/config.
+func DefaultConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".lightstep", "config")
+}
+
+// ResolveCredentials resolves the API key in this order: the explicit key, the key command,
+// the selected profile, the environment variable and finally the default profile.
+// The organization and API URL come from the explicit values or else from the profile.
+func ResolveCredentials(ctx context.Context, cfg CredentialsConfig) (Credentials, error) {
+	creds := Credentials{APIKey: cfg.APIKey, Org: cfg.Org, APIURL: cfg.APIURL}
+
+	profileName := cfg.Profile
+	if profileName == "" {
+		profileName = DefaultProfile
+	}
+	configFile := cfg.ConfigFile
+	if configFile == "" {
+		configFile = DefaultConfigFile()
+	}
+
+	profiles, err := readProfiles(configFile)
+	if err != nil && !(os.IsNotExist(err) && cfg.Profile == "") {
+		return creds, err
+	}
+	profile, ok := profiles[profileName]
+	if !ok && cfg.Profile != "" {
+		return creds, fmt.Errorf("profile %q not found in %s", cfg.Profile, configFile)
+	}
+
+	if creds.Org == "" {
+		creds.Org = profile.Org
+	}
+	if creds.APIURL == "" && !cfg.KeepAPIURL {
+		creds.APIURL = profile.APIURL
+	}
+
+	if creds.APIKey != "" {
+		return creds, nil
+	}
+	if cfg.APIKeyCommand != "" {
+		creds.APIKey, err = runAPIKeyCommand(ctx, cfg.APIKeyCommand)
+		return creds, err
+	}
+
+	// an explicitly selected profile wins over the environment, the default one does not
+	if cfg.Profile == "" && cfg.APIKeyEnvVar != "" {
+		if key := os.Getenv(cfg.APIKeyEnvVar); key != "" {
+			creds.APIKey = key
+			return creds, nil
+		}
+	}
+
+	switch {
+	case profile.APIKey != "":
+		creds.APIKey = profile.APIKey
+	case profile.APIKeyCommand != "":
+		creds.APIKey, err = runAPIKeyCommand(ctx, profile.APIKeyCommand)
+	case cfg.APIKeyEnvVar != "":
+		creds.APIKey = os.Getenv(cfg.APIKeyEnvVar)
+	}
+	return creds, err
+}
+
+// readProfiles parses an INI style profiles file.
+func readProfiles(path string) (map[string]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := map[string]Profile{}
+	var name string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name = strings.TrimSpace(strings.TrimPrefix(strings.Trim(line, "[]"), "profile "))
+			profiles[name] = profiles[name]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] header or a key = value pair", path, lineNum)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		profile := profiles[name]
+		switch key {
+		case "organization", "org":
+			profile.Org = value
+		case "api_url":
+			profile.APIURL = value
+		case "api_key":
+			profile.APIKey = value
+		case "api_key_command":
+			profile.APIKeyCommand = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown key %q", path, lineNum, key)
+		}
+		profiles[name] = profile
+	}
+	return profiles, scanner.Err()
+}
+
+var (
+	apiKeyCommandMu    sync.Mutex
+	apiKeyCommandCache = map[string]string{}
+)
+
+// runAPIKeyCommand runs command and returns the trimmed standard output. Successful results
+// are cached for the lifetime of the provider process. Terraform starts a new one for every
+// plan and apply and for every provider alias, so the helper still runs once for each.
+func runAPIKeyCommand(ctx context.Context, command string) (string, error) {
+	apiKeyCommandMu.Lock()
+	defer apiKeyCommandMu.Unlock()
+
+	if key, ok := apiKeyCommandCache[command]; ok {
+		return key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiKeyCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("api key command failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("api key command printed nothing to standard output")
+	}
+	apiKeyCommandCache[command] = key
+	return key, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfiles = `
+# shared settings
+[default]
+organization = default-org
+api_key      = default-key
+
+[profile staging]
+organization = staging-org
+api_url      = https://api-staging.lightstep.com
+api_key_command = echo staging-key
+`
+
+func writeProfiles(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func Test_ResolveCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	t.Setenv("TEST_LIGHTSTEP_API_KEY", "env-key")
+	configFile := writeProfiles(t, testProfiles)
+
+	type testCase struct {
+		name     string
+		cfg      CredentialsConfig
+		expected Credentials
+	}
+
+	testCases := []testCase{
+		{
+			name:     "explicit values win",
+			cfg:      CredentialsConfig{APIKey: "key", Org: "org", APIURL: "url", APIKeyEnvVar: "TEST_LIGHTSTEP_API_KEY", Profile: "staging", ConfigFile: configFile},
+			expected: Credentials{APIKey: "key", Org: "org", APIURL: "url"},
+		},
+		{
+			name:     "key command",
+			cfg:      CredentialsConfig{APIKeyCommand: "echo '  command-key '", APIKeyEnvVar: "TEST_LIGHTSTEP_API_KEY", ConfigFile: configFile},
+			expected: Credentials{APIKey: "command-key", Org: "default-org"},
+		},
+		{
+			name:     "environment wins over the default profile",
+			cfg:      CredentialsConfig{APIKeyEnvVar: "TEST_LIGHTSTEP_API_KEY", ConfigFile: configFile},
+			expected: Credentials{APIKey: "env-key", Org: "default-org"},
+		},
+		{
+			name:     "selected profile wins over the environment",
+			cfg:      CredentialsConfig{APIKeyEnvVar: "TEST_LIGHTSTEP_API_KEY", Profile: "staging", ConfigFile: configFile},
+			expected: Credentials{APIKey: "staging-key", Org: "staging-org", APIURL: "https://api-staging.lightstep.com"},
+		},
+		{
+			name:     "API URL kept out of the profile",
+			cfg:      CredentialsConfig{KeepAPIURL: true, Profile: "staging", ConfigFile: configFile},
+			expected: Credentials{APIKey: "staging-key", Org: "staging-org"},
+		},
+		{
+			name:     "default profile",
+			cfg:      CredentialsConfig{APIKeyEnvVar: "UNSET_LIGHTSTEP_API_KEY", ConfigFile: configFile},
+			expected: Credentials{APIKey: "default-key", Org: "default-org"},
+		},
+		{
+			name:     "missing default config file",
+			cfg:      CredentialsConfig{APIKeyEnvVar: "TEST_LIGHTSTEP_API_KEY", ConfigFile: filepath.Join(t.TempDir(), "missing")},
+			expected: Credentials{APIKey: "env-key"},
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			creds, err := ResolveCredentials(context.Background(), c.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, creds)
+		})
+	}
+}
+
+func Test_ResolveCredentials_errors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	configFile := writeProfiles(t, testProfiles)
+
+	_, err := ResolveCredentials(context.Background(), CredentialsConfig{Profile: "prod", ConfigFile: configFile})
+	assert.ErrorContains(t, err, `profile "prod" not found`)
+
+	_, err = ResolveCredentials(context.Background(), CredentialsConfig{Profile: "prod", ConfigFile: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+
+	_, err = ResolveCredentials(context.Background(), CredentialsConfig{ConfigFile: writeProfiles(t, "[default]\ntoken = abc\n")})
+	assert.ErrorContains(t, err, `config:2: unknown key "token"`)
+
+	_, err = ResolveCredentials(context.Background(), CredentialsConfig{APIKeyCommand: "echo oops >&2; exit 3"})
+	assert.ErrorContains(t, err, "oops")
+
+	_, err = ResolveCredentials(context.Background(), CredentialsConfig{APIKeyCommand: "true"})
+	assert.ErrorContains(t, err, "printed nothing")
+}
+
+func Test_runAPIKeyCommand_caches(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	counter := filepath.Join(t.TempDir(), "count")
+	command := "echo run >> " + counter + " && echo cached-key"
+
+	for i := 0; i < 3; i++ {
+		key, err := runAPIKeyCommand(context.Background(), command)
+		require.NoError(t, err)
+		assert.Equal(t, "cached-key", key)
+	}
+
+	runs, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", string(runs))
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	// maxLoggedBodyBytes bounds how much of a request or response body ends up in the debug log
+	maxLoggedBodyBytes = 4096
+	redacted           = RedactedValue
+)
+
+// RedactedValue replaces the secrets masked by RedactJSON.
+const RedactedValue = "***"
+
+// sensitiveHeaders are never written to the debug log.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveFields are attributes whose values are masked wherever they appear in a body.
+var sensitiveFields = map[string]bool{
+	"api_key":         true,
+	"integration_key": true,
+	"password":        true,
+	"signing_secret":  true,
+}
+
+// sensitiveFieldsByType are attributes masked only in destinations of a given type. The URL of
+// a Microsoft Teams webhook is its credential.
+var sensitiveFieldsByType = map[string]map[string]bool{
+	"msteams": {"url": true},
+}
+
+// sensitiveMaps are attributes whose keys are kept but whose values are all masked.
+var sensitiveMaps = map[string]bool{
+	"custom_headers": true,
+}
+
+// debugLogEnabled reports whether API traffic should be logged, i.e. TF_LOG is DEBUG or TRACE.
+func debugLogEnabled() bool {
+	return logging.IsDebugOrHigher()
+}
+
+func logAPIRequest(method string, url string, header http.Header, body []byte) {
+	log.Printf("[DEBUG] Lightstep API request: %s %s\nheaders: %s\nbody: %s",
+		method, url, redactHeaders(header), redactBody(body))
+}
+
+func logAPIResponse(method string, url string, resp *http.Response, latency time.Duration, body []byte) {
+	log.Printf("[DEBUG] Lightstep API response: %s %s: %s in %v\nbody: %s",
+		method, url, resp.Status, latency.Round(time.Millisecond), redactBody(body))
+}
+
+// redactHeaders formats header, masking credentials.
+func redactHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header.Values(name), ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", name, value))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// redactBody masks secrets in a JSON body and truncates it to maxLoggedBodyBytes.
+// Bodies that aren't JSON are only truncated.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return "(empty)"
+	}
+
+	body = RedactJSON(body)
+	if len(body) > maxLoggedBodyBytes {
+		return fmt.Sprintf("%s... (%d more bytes)", body[:maxLoggedBodyBytes], len(body)-maxLoggedBodyBytes)
+	}
+	return string(body)
+}
+
+// RedactJSON masks the secrets of a JSON body, such as destination credentials, the same way
+// the debug log does. Bodies that aren't JSON are returned unchanged.
+func RedactJSON(body []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+	masked, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return body
+	}
+	return masked
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		destinationType, _ := v["destination_type"].(string)
+		for key, value := range v {
+			switch {
+			case sensitiveFields[key] || sensitiveFieldsByType[destinationType][key]:
+				if value != nil && value != "" {
+					v[key] = redacted
+				}
+			case sensitiveMaps[key]:
+				if m, ok := value.(map[string]interface{}); ok {
+					for k := range m {
+						m[k] = redacted
+					}
+				}
+			default:
+				v[key] = redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
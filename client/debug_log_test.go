@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_redactBody(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"data": {"attributes": {
+		"name": "on-call",
+		"integration_key": "pd-secret",
+		"api_key": "og-secret",
+		"signing_secret": "hmac-secret",
+		"auth": {"username": "admin", "password": "sn-secret"},
+		"custom_headers": {"X-Token": "hook-secret", "X-Env": "prod"}
+	}}}`)
+
+	out := redactBody(body)
+	assert.NotContains(t, out, "pd-secret")
+	assert.NotContains(t, out, "sn-secret")
+	assert.NotContains(t, out, "og-secret")
+	assert.NotContains(t, out, "hmac-secret")
+	assert.NotContains(t, out, "hook-secret")
+	assert.NotContains(t, out, "prod")
+	assert.Contains(t, out, `"username":"admin"`)
+	assert.Contains(t, out, `"X-Token":"***"`)
+	assert.Contains(t, out, `"integration_key":"***"`)
+
+	assert.Equal(t, "(empty)", redactBody(nil))
+	assert.Equal(t, "not json", redactBody([]byte("not json")))
+
+	// webhook URLs are only secret for Teams
+	out = redactBody([]byte(`[
+		{"attributes": {"destination_type": "msteams", "url": "https://example.webhook.office.com/teams-secret"}},
+		{"attributes": {"destination_type": "webhook", "url": "https://hooks.example.com/alerts"}}
+	]`))
+	assert.NotContains(t, out, "teams-secret")
+	assert.Contains(t, out, "https://hooks.example.com/alerts")
+
+	long := redactBody([]byte(strings.Repeat("a", maxLoggedBodyBytes+10)))
+	assert.True(t, strings.HasSuffix(long, "... (10 more bytes)"))
+}
+
+func Test_CallAPI_logs_redacted_traffic_at_debug(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"data": {"id": "d1", "attributes": {"integration_key": "pd-secret"}}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("super-secret-api-key", "blars", server.URL)
+	_, err := c.CreateDestination(context.Background(), "tacoman", Destination{
+		Type: "destination",
+		Attributes: PagerdutyAttributes{
+			Name:            "on-call",
+			IntegrationKey:  "pd-secret",
+			DestinationType: "pagerduty",
+		},
+	})
+	require.NoError(t, err)
+
+	out := logs.String()
+	assert.Contains(t, out, "Lightstep API request: POST "+server.URL+"/public/v0.2/blars/projects/tacoman/destinations")
+	assert.Contains(t, out, "Authorization: ***")
+	assert.Contains(t, out, "Lightstep API response: POST")
+	assert.Contains(t, out, "200 OK in ")
+	assert.NotContains(t, out, "super-secret-api-key")
+	assert.NotContains(t, out, "pd-secret")
+}
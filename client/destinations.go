@@ -4,21 +4,86 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type Destination struct {
-	Type       string      `json:"type"`
+	Type string `json:"type"`
+	// Attributes is one of the typed *Attributes structs below. Destinations read from the
+	// API whose destination_type the client doesn't know keep their attributes as a map.
 	Attributes interface{} `json:"attributes"`
 	ID         string      `json:"id"`
 }
 
+// UnmarshalJSON decodes the attributes into the struct matching their destination_type.
+func (d *Destination) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type       string          `json:"type"`
+		Attributes json.RawMessage `json:"attributes"`
+		ID         string          `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.Type, d.ID, d.Attributes = raw.Type, raw.ID, nil
+	if len(raw.Attributes) == 0 || string(raw.Attributes) == "null" {
+		return nil
+	}
+
+	var kind struct {
+		DestinationType string `json:"destination_type"`
+	}
+	if err := json.Unmarshal(raw.Attributes, &kind); err != nil {
+		return err
+	}
+
+	var err error
+	switch kind.DestinationType {
+	case "webhook":
+		var attrs WebhookAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "pagerduty":
+		var attrs PagerdutyAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "slack":
+		var attrs SlackAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "servicenow":
+		var attrs ServiceNowAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "email":
+		var attrs EmailAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "msteams":
+		var attrs MSTeamsAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	case "opsgenie":
+		var attrs OpsgenieAttributes
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	default:
+		var attrs map[string]interface{}
+		err = json.Unmarshal(raw.Attributes, &attrs)
+		d.Attributes = attrs
+	}
+	return err
+}
+
 type WebhookAttributes struct {
 	Name            string                 `json:"name"`
 	DestinationType string                 `json:"destination_type"`
 	URL             string                 `json:"url"`
 	Template        string                 `json:"template"`
 	CustomHeaders   map[string]interface{} `json:"custom_headers,omitempty"`
+	// SigningSecret is the key payloads are signed with using HMAC-SHA256. Like passwords,
+	// it's only set for requests. Leaving it nil keeps the current secret and pointing it at
+	// an empty string removes it.
+	SigningSecret *string `json:"signing_secret,omitempty"`
 }
 
 type PagerdutyAttributes struct {
@@ -39,10 +104,52 @@ type ServiceNowAttributes struct {
 	Auth            Auth   `json:"auth"`
 }
 
+type EmailAttributes struct {
+	Name            string   `json:"name"`
+	DestinationType string   `json:"destination_type"`
+	Recipients      []string `json:"recipients"`
+	// SubjectTemplate is the template of the email's subject, the default subject is used when empty
+	SubjectTemplate string `json:"subject_template,omitempty"`
+}
+
+type MSTeamsAttributes struct {
+	Name            string `json:"name"`
+	DestinationType string `json:"destination_type"`
+	// URL is the Teams channel's incoming webhook URL
+	URL string `json:"url"`
+	// Template is an adaptive card template, the default card is sent when empty
+	Template string `json:"template,omitempty"`
+}
+
+type OpsgenieAttributes struct {
+	Name            string `json:"name"`
+	DestinationType string `json:"destination_type"`
+	// APIKey is the key of an Opsgenie API integration
+	APIKey string `json:"api_key"`
+	// Region is the Opsgenie instance alerts are sent to, either "us" or "eu"
+	Region          string                   `json:"region"`
+	Responders      []OpsgenieResponder      `json:"responders,omitempty"`
+	PriorityMapping *OpsgeniePriorityMapping `json:"priority_mapping,omitempty"`
+}
+
+// OpsgenieResponder is a team, user, escalation or schedule notified of an alert.
+type OpsgenieResponder struct {
+	Type string `json:"type"`
+	// Name is the name of the team, escalation or schedule, or the username of the user
+	Name string `json:"name"`
+}
+
+// OpsgeniePriorityMapping maps alert statuses to Opsgenie priorities, from P1 to P5.
+type OpsgeniePriorityMapping struct {
+	Warning  string `json:"warning"`
+	Critical string `json:"critical"`
+}
+
 type Auth struct {
 	Username string `json:"username"`
-	// Password is only set for requests. Will be empty for API responses
-	Password string `json:"password"`
+	// Password is only set for requests. Will be empty for API responses, and is left
+	// unchanged by updates that don't set it
+	Password string `json:"password,omitempty"`
 }
 
 func (c *Client) CreateDestination(
@@ -67,6 +174,32 @@ func (c *Client) CreateDestination(
 	return dest, err
 }
 
+// UpdateDestination replaces the attributes of an existing destination, keeping its ID so
+// alerting rules that reference it stay attached.
+func (c *Client) UpdateDestination(
+	ctx context.Context,
+	project string,
+	destinationID string,
+	destination Destination) (Destination, error) {
+
+	var resp Envelope
+	var dest Destination
+
+	destination.ID = destinationID
+	bytes, err := json.Marshal(destination)
+	if err != nil {
+		return dest, err
+	}
+
+	err = c.CallAPI(ctx, "PUT", fmt.Sprintf("projects/%v/destinations/%v", project, destinationID), Envelope{Data: bytes}, &resp)
+	if err != nil {
+		return dest, err
+	}
+
+	err = json.Unmarshal(resp.Data, &dest)
+	return dest, err
+}
+
 func (c *Client) GetDestination(ctx context.Context, projectName string, destinationID string) (*Destination, error) {
 	var (
 		dest *Destination
@@ -86,13 +219,51 @@ func (c *Client) GetDestination(ctx context.Context, projectName string, destina
 	return dest, err
 }
 
+// DestinationTestResult is the outcome of sending a test notification to a destination.
+type DestinationTestResult struct {
+	Delivered bool `json:"delivered"`
+	// StatusCode is the HTTP status code the destination responded with, zero when it
+	// couldn't be reached
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
+
+// TestDestination sends a test notification to a destination. A notification that isn't
+// delivered is reported in the result, not as an error.
+func (c *Client) TestDestination(ctx context.Context, projectName string, destinationID string) (DestinationTestResult, error) {
+	var (
+		resp   Envelope
+		result struct {
+			Attributes DestinationTestResult `json:"attributes"`
+		}
+	)
+
+	err := c.CallAPI(ctx, "POST",
+		fmt.Sprintf("projects/%v/destinations/%v/test", projectName, destinationID),
+		nil,
+		&resp)
+	if err != nil {
+		return result.Attributes, err
+	}
+
+	err = json.Unmarshal(resp.Data, &result)
+	return result.Attributes, err
+}
+
+// IterateDestinations returns an iterator over every destination in the project.
+func (c *Client) IterateDestinations(projectName string) *Iterator[Destination] {
+	return newIterator[Destination](c, fmt.Sprintf("projects/%v/destinations", projectName))
+}
+
+// ListDestinations returns every destination in the project, following pagination links.
+func (c *Client) ListDestinations(ctx context.Context, projectName string) ([]Destination, error) {
+	return c.IterateDestinations(projectName).All(ctx)
+}
+
 func (c *Client) DeleteDestination(ctx context.Context, project string, destinationID string) error {
 	err := c.CallAPI(ctx, "DELETE", fmt.Sprintf("projects/%v/destinations/%v", project, destinationID), nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
@@ -2,11 +2,14 @@ package client
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_DeleteDestionation_when_connection_is_closed(t *testing.T) {
@@ -41,3 +44,78 @@ func Test_DeleteDestination_when_connection_has_wrong_content_length(t *testing.
 	assert.NotNil(t, err)
 	assert.Equal(t, "unexpected EOF", err.Error())
 }
+
+func Test_GetDestination_decodes_typed_attributes(t *testing.T) {
+	responses := map[string]string{
+		"webhook":   `{"data": {"id": "webhook", "type": "destination", "attributes": {"destination_type": "webhook", "name": "hook", "url": "https://example.com", "template": "{}", "custom_headers": {"x": "y"}}}}`,
+		"pagerduty": `{"data": {"id": "pagerduty", "type": "destination", "attributes": {"destination_type": "pagerduty", "name": "pd", "integration_key": "key"}}}`,
+		"unknown":   `{"data": {"id": "unknown", "type": "destination", "attributes": {"destination_type": "carrier_pigeon", "name": "coo"}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(responses[path.Base(r.URL.Path)]))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	ctx := context.Background()
+
+	dest, err := c.GetDestination(ctx, "tacoman", "webhook")
+	require.NoError(t, err)
+	assert.Equal(t, WebhookAttributes{
+		Name:            "hook",
+		DestinationType: "webhook",
+		URL:             "https://example.com",
+		Template:        "{}",
+		CustomHeaders:   map[string]interface{}{"x": "y"},
+	}, dest.Attributes)
+
+	dest, err = c.GetDestination(ctx, "tacoman", "pagerduty")
+	require.NoError(t, err)
+	assert.Equal(t, PagerdutyAttributes{Name: "pd", IntegrationKey: "key", DestinationType: "pagerduty"}, dest.Attributes)
+
+	dest, err = c.GetDestination(ctx, "tacoman", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", dest.ID)
+	assert.Equal(t, map[string]interface{}{"destination_type": "carrier_pigeon", "name": "coo"}, dest.Attributes)
+}
+
+func Test_UpdateDestination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/public/v0.2/blars/projects/tacoman/destinations/hi", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": {"id": "hi", "type": "destination", "attributes": {"destination_type": "slack", "channel": "#alerts"}}}`, string(body))
+
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	dest, err := c.UpdateDestination(context.Background(), "tacoman", "hi", Destination{
+		Type:       "destination",
+		Attributes: SlackAttributes{Channel: "#alerts", DestinationType: "slack"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hi", dest.ID)
+	assert.Equal(t, SlackAttributes{Channel: "#alerts", DestinationType: "slack"}, dest.Attributes)
+}
+
+func Test_TestDestination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/public/v0.2/blars/projects/tacoman/destinations/hi/test", r.URL.Path)
+
+		_, err := w.Write([]byte(`{"data": {"type": "destination_test", "attributes": {"delivered": false, "status_code": 410, "message": "Gone"}}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	result, err := c.TestDestination(context.Background(), "tacoman", "hi")
+	require.NoError(t, err)
+	assert.Equal(t, DestinationTestResult{Delivered: false, StatusCode: 410, Message: "Gone"}, result)
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is a single entry of the JSON:API "errors" array returned by the Lightstep Public API.
+type APIError struct {
+	Status string          `json:"status,omitempty"`
+	Code   string          `json:"code,omitempty"`
+	Title  string          `json:"title,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Source *APIErrorSource `json:"source,omitempty"`
+}
+
+// APIErrorSource identifies the part of the request that caused an APIError.
+type APIErrorSource struct {
+	// Pointer is a JSON pointer into the request document, e.g. "/data/attributes/name"
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// Pointer returns the JSON pointer of the request field that caused the error, if any.
+func (e APIError) Pointer() string {
+	if e.Source == nil {
+		return ""
+	}
+	return e.Source.Pointer
+}
+
+// Message returns the human readable part of the error, without its source.
+func (e APIError) Message() string {
+	var parts []string
+	if e.Title != "" {
+		parts = append(parts, e.Title)
+	}
+	if e.Detail != "" && e.Detail != e.Title {
+		parts = append(parts, e.Detail)
+	}
+	if len(parts) == 0 && e.Code != "" {
+		parts = append(parts, e.Code)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (e APIError) String() string {
+	msg := e.Message()
+	if pointer := e.Pointer(); pointer != "" {
+		msg = fmt.Sprintf("%s (at %s)", msg, pointer)
+	}
+	return msg
+}
+
+// decodeAPIErrors extracts the JSON:API "errors" array from a response body.
+// Older endpoints return the errors as plain strings, so both shapes are accepted.
+func decodeAPIErrors(body []byte) []APIError {
+	var doc struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+
+	var apiErrors []APIError
+	for _, raw := range doc.Errors {
+		var detail string
+		if err := json.Unmarshal(raw, &detail); err == nil {
+			apiErrors = append(apiErrors, APIError{Detail: detail})
+			continue
+		}
+
+		var apiErr APIError
+		if err := json.Unmarshal(raw, &apiErr); err == nil {
+			apiErrors = append(apiErrors, apiErr)
+		}
+	}
+	return apiErrors
+}
+
+func newAPIClientError(resp *http.Response, body []byte) APIClientError {
+	apiErrors := decodeAPIErrors(body)
+	if len(apiErrors) == 0 {
+		return APIClientError{
+			Response: resp,
+			Message:  fmt.Sprintf("status %d (%s): %q", resp.StatusCode, resp.Status, string(body)),
+		}
+	}
+
+	details := make([]string, 0, len(apiErrors))
+	for _, e := range apiErrors {
+		details = append(details, e.String())
+	}
+	return APIClientError{
+		Response: resp,
+		Message:  fmt.Sprintf("status %d (%s): %s", resp.StatusCode, resp.Status, strings.Join(details, "; ")),
+		Errors:   apiErrors,
+	}
+}
+
+// statusCode returns the HTTP status code carried by err, or -1 if there is none.
+func statusCode(err error) int {
+	var carrier APIResponseCarrier
+	if !errors.As(err, &carrier) {
+		return -1
+	}
+	return carrier.GetStatusCode()
+}
+
+// IsNotFound reports whether err is an API error for a resource that does not exist.
+func IsNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an API error caused by a missing, invalid or expired API key.
+func IsUnauthorized(err error) bool {
+	return statusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is an API error caused by the API key lacking permission.
+func IsForbidden(err error) bool {
+	return statusCode(err) == http.StatusForbidden
+}
+
+// IsConflict reports whether err is an API error caused by a conflict with the current state of a resource.
+func IsConflict(err error) bool {
+	return statusCode(err) == http.StatusConflict
+}
+
+// IsValidation reports whether err is an API error caused by the request failing validation.
+func IsValidation(err error) bool {
+	code := statusCode(err)
+	return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+}
+
+// IsRateLimited reports whether err is an API error caused by exceeding the rate limit.
+func IsRateLimited(err error) bool {
+	return statusCode(err) == http.StatusTooManyRequests
+}
+
+// isNoContent reports whether err only signals an empty (204) response, which DELETE endpoints
+// use to indicate success.
+func isNoContent(err error) bool {
+	return statusCode(err) == http.StatusNoContent
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type EventQueryAttributes struct {
@@ -37,6 +36,17 @@ func (c *Client) GetEventQuery(ctx context.Context, projectName string, eventQue
 	return &event.Attributes, nil
 }
 
+// IterateEventQueries returns an iterator over every event query in the project.
+func (c *Client) IterateEventQueries(projectName string) *Iterator[EventQueryAttributes] {
+	return newConvertingIterator(c, fmt.Sprintf("projects/%v/event_queries", projectName),
+		func(event WireEventQueryAttributes) EventQueryAttributes { return event.Attributes })
+}
+
+// ListEventQueries returns every event query in the project, following pagination links.
+func (c *Client) ListEventQueries(ctx context.Context, projectName string) ([]EventQueryAttributes, error) {
+	return c.IterateEventQueries(projectName).All(ctx)
+}
+
 func (c *Client) CreateEventQuery(ctx context.Context, projectName string, attributes EventQueryAttributes) (*EventQueryAttributes, error) {
 	var (
 		event *EventQueryAttributes
@@ -80,11 +90,8 @@ func (c *Client) DeleteEventQuery(ctx context.Context, projectName string, event
 		fmt.Sprintf("projects/%v/event_queries/%v", projectName, eventQueryID),
 		nil,
 		nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
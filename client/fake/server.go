@@ -0,0 +1,570 @@
+// Package fake provides an in-memory implementation of the Lightstep public API for
+// running client and provider tests offline.
+//
+//	server := fake.NewServer()
+//	defer server.Close()
+//
+//	c := server.NewClient()
+//	// or point the provider's api_url at server.URL, with server.Org and server.APIKey
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+const (
+	DefaultAPIKey = "fake-api-key"
+	DefaultOrg    = "fake-org"
+
+	contentType = "application/vnd.api+json"
+)
+
+// collections are the project scoped resources stored by the server, keyed by the last
+// segment of their URL.
+var collections = map[string]bool{
+	"metric_dashboards":      true,
+	"metric_alerts":          true,
+	"destinations":           true,
+	"streams":                true,
+	"conditions":             true,
+	"dashboards":             true,
+	"alerting_rules":         true,
+	"snooze_rules":           true,
+	"event_queries":          true,
+	"inferred_service_rules": true,
+}
+
+// Object is a JSON:API resource object as stored by the server.
+type Object = map[string]interface{}
+
+// Server is an httptest.Server backed by in-memory state. Each instance is independent.
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the only key accepted in the Authorization header.
+	APIKey string
+	// Org is the only organization served.
+	Org string
+	// PageSize splits collections into pages linked with links.next when greater than zero.
+	PageSize int
+	// ValidateSnoozeRule decides the outcome of snooze_rules_validate. Every rule is valid when nil.
+	ValidateSnoozeRule func(rule Object) (valid bool, validationError string)
+	// TranslateQuery returns the UQL equivalent of a legacy query for query_translation.
+	// Queries are returned unchanged when nil.
+	TranslateQuery func(query Object) string
+	// TestDestination decides the outcome of a test notification sent to a destination.
+	// Every notification is delivered with a 200 when nil.
+	TestDestination func(destination Object) (statusCode int, message string)
+	// Projects are listed by the projects endpoint, along with every project holding objects.
+	Projects []string
+	// ReadOnly rejects requests that would change state with a 403, like a key with the Viewer role.
+	ReadOnly bool
+
+	mu           sync.Mutex
+	nextID       int
+	objects      map[string]map[string]Object // "project/collection" -> id -> object
+	roleBindings map[string]client.RoleBinding
+	samlMappings client.SAMLGroupMappings
+}
+
+// NewServer starts a fake API accepting DefaultAPIKey for DefaultOrg.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:       DefaultAPIKey,
+		Org:          DefaultOrg,
+		objects:      map[string]map[string]Object{},
+		roleBindings: map[string]client.RoleBinding{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a client talking to the server. It isn't rate limited unless opts say otherwise.
+func (s *Server) NewClient(opts ...client.Option) *client.Client {
+	opts = append([]client.Option{client.WithRateLimit(rate.Inf)}, opts...)
+	return client.NewClient(s.APIKey, s.Org, s.URL, opts...)
+}
+
+// Get returns a copy of a stored resource object.
+func (s *Server) Get(project string, collection string, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[collectionKey(project, collection)][id]
+	if !ok {
+		return nil, false
+	}
+	return clone(obj), true
+}
+
+// List returns copies of every object in a collection, ordered by ID.
+func (s *Server) List(project string, collection string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list(project, collection)
+}
+
+// Put stores obj, e.g. to seed state created outside of Terraform. An ID is assigned when
+// obj has none; the stored ID is returned.
+func (s *Server) Put(project string, collection string, obj Object) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(project, collection, clone(obj))
+}
+
+// Delete removes an object, e.g. to simulate a resource deleted outside of Terraform.
+func (s *Server) Delete(project string, collection string, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects[collectionKey(project, collection)], id)
+}
+
+// RoleBinding returns the users bound to a role, in a project or organization wide.
+func (s *Server) RoleBinding(project string, role string) client.RoleBinding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.roleBinding(project, role)
+}
+
+// SAMLGroupMappings returns the organization's SAML group mappings.
+func (s *Server) SAMLGroupMappings() client.SAMLGroupMappings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.samlMappings
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "bearer "+s.APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	prefix := fmt.Sprintf("/public/v0.2/%s/", s.Org)
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown organization in %s", r.URL.Path))
+		return
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ReadOnly && r.Method != http.MethodGet && !isReadOnlyEndpoint(segments) {
+		writeError(w, http.StatusForbidden, "API key does not have write permission")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && segments[0] == "projects":
+		s.serveProjects(w, r)
+	case len(segments) == 1 && segments[0] == "role-binding":
+		s.serveRoleBinding(w, r)
+	case len(segments) == 1 && segments[0] == "saml-group-mappings":
+		s.serveSAMLGroupMappings(w, r)
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "snooze_rules_validate":
+		s.serveValidateSnoozeRule(w, r)
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "query_translation":
+		s.serveQueryTranslation(w, r)
+	case len(segments) == 3 && segments[0] == "projects" && collections[segments[2]]:
+		s.serveCollection(w, r, segments[1], segments[2])
+	case len(segments) == 5 && segments[0] == "projects" && segments[2] == "destinations" && segments[4] == "test":
+		s.serveTestDestination(w, r, segments[1], segments[3])
+	case len(segments) == 4 && segments[0] == "projects" && collections[segments[2]]:
+		s.serveObject(w, r, segments[1], segments[2], segments[3])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// isReadOnlyEndpoint reports whether a POST to the path only computes a result.
+func isReadOnlyEndpoint(segments []string) bool {
+	return len(segments) == 3 && segments[0] == "projects" &&
+		(segments[2] == "snooze_rules_validate" || segments[2] == "query_translation")
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "projects only supports GET")
+		return
+	}
+
+	names := map[string]bool{}
+	for _, name := range s.Projects {
+		names[name] = true
+	}
+	for key, objects := range s.objects {
+		if len(objects) > 0 {
+			name, _ := url.PathUnescape(strings.SplitN(key, "/", 2)[0])
+			names[name] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	projects := make([]Object, 0, len(sorted))
+	for _, name := range sorted {
+		projects = append(projects, Object{"id": name, "type": "project", "attributes": Object{"name": name}})
+	}
+	writeData(w, projects)
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, project string, collection string) {
+	switch r.Method {
+	case http.MethodGet:
+		items := s.list(project, collection)
+		for i := range items {
+			items[i] = present(collection, items[i])
+		}
+
+		body := map[string]interface{}{"data": items}
+		if s.PageSize > 0 {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			start := page * s.PageSize
+			if start > len(items) {
+				start = len(items)
+			}
+			end := start + s.PageSize
+			if end < len(items) {
+				body["links"] = map[string]string{"next": fmt.Sprintf("%s?page=%d", r.URL.Path, page+1)}
+			} else {
+				end = len(items)
+			}
+			body["data"] = items[start:end]
+		}
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodPost:
+		obj, ok := decodeData(w, r)
+		if !ok {
+			return
+		}
+		delete(obj, "id")
+		id := s.put(project, collection, obj)
+		writeData(w, present(collection, s.objects[collectionKey(project, collection)][id]))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s is not supported on %s", r.Method, collection))
+	}
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, project string, collection string, id string) {
+	objects := s.objects[collectionKey(project, collection)]
+	existing, ok := objects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found in project %q", collection, id, project))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, present(collection, existing))
+	case http.MethodPut, http.MethodPatch:
+		obj, ok := decodeData(w, r)
+		if !ok {
+			return
+		}
+		updated := clone(existing)
+		attributes, _ := obj["attributes"].(map[string]interface{})
+		if r.Method == http.MethodPatch {
+			current, _ := updated["attributes"].(map[string]interface{})
+			if current == nil {
+				current = map[string]interface{}{}
+			}
+			for k, v := range attributes {
+				current[k] = v
+			}
+			attributes = current
+		}
+		updated["attributes"] = attributes
+		if relationships, ok := obj["relationships"]; ok {
+			updated["relationships"] = relationships
+		}
+		s.put(project, collection, withID(collection, updated, id))
+		writeData(w, present(collection, objects[id]))
+	case http.MethodDelete:
+		delete(objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s is not supported on %s", r.Method, collection))
+	}
+}
+
+func (s *Server) serveValidateSnoozeRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "snooze_rules_validate only supports POST")
+		return
+	}
+	rule, ok := decodeData(w, r)
+	if !ok {
+		return
+	}
+
+	valid, validationError := true, ""
+	if s.ValidateSnoozeRule != nil {
+		valid, validationError = s.ValidateSnoozeRule(rule)
+	}
+	writeData(w, map[string]interface{}{"is_valid": valid, "validation_error": validationError})
+}
+
+func (s *Server) serveTestDestination(w http.ResponseWriter, r *http.Request, project string, id string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "destination test only supports POST")
+		return
+	}
+	destination, ok := s.objects[collectionKey(project, "destinations")][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("destinations %q not found in project %q", id, project))
+		return
+	}
+
+	statusCode, message := http.StatusOK, "OK"
+	if s.TestDestination != nil {
+		statusCode, message = s.TestDestination(clone(destination))
+	}
+	writeData(w, Object{
+		"type": "destination_test",
+		"attributes": Object{
+			"delivered":   statusCode >= 200 && statusCode < 300,
+			"status_code": statusCode,
+			"message":     message,
+		},
+	})
+}
+
+func (s *Server) serveQueryTranslation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "query_translation only supports POST")
+		return
+	}
+	data, ok := decodeData(w, r)
+	if !ok {
+		return
+	}
+
+	queries, _ := data["queries"].([]interface{})
+	translated := make([]map[string]interface{}, 0, len(queries))
+	for _, q := range queries {
+		query, _ := q.(map[string]interface{})
+		uql, _ := query["query-string"].(string)
+		if s.TranslateQuery != nil {
+			uql = s.TranslateQuery(query)
+		}
+		translated = append(translated, map[string]interface{}{
+			"query-name": query["query-name"],
+			"tql-query":  uql,
+		})
+	}
+	writeData(w, map[string]interface{}{"queries": translated})
+}
+
+func (s *Server) serveRoleBinding(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		binding := s.roleBinding(r.URL.Query().Get("project"), r.URL.Query().Get("role-name"))
+		writeData(w, map[string]interface{}{"attributes": binding})
+	case http.MethodPost:
+		var req struct {
+			Data client.RoleBinding `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+		if req.Data.RoleName == "" {
+			writeError(w, http.StatusBadRequest, "role-name is required")
+			return
+		}
+		users := append([]string{}, req.Data.Users...)
+		sort.Strings(users)
+		req.Data.Users = users
+		s.roleBindings[roleBindingKey(req.Data.ProjectName, req.Data.RoleName)] = req.Data
+		writeData(w, req.Data)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "role-binding only supports GET and POST")
+	}
+}
+
+func (s *Server) serveSAMLGroupMappings(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, map[string]interface{}{"attributes": s.samlMappings})
+	case http.MethodPost:
+		var req struct {
+			Data struct {
+				Attributes client.SAMLGroupMappings `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+		s.samlMappings = req.Data.Attributes
+		writeData(w, map[string]interface{}{"attributes": s.samlMappings})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "saml-group-mappings only supports GET and POST")
+	}
+}
+
+func (s *Server) list(project string, collection string) []Object {
+	objects := s.objects[collectionKey(project, collection)]
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	items := make([]Object, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, clone(objects[id]))
+	}
+	return items
+}
+
+// put stores obj under its ID, generating one (and IDs for nested charts and groups) when missing.
+// Callers must hold s.mu.
+func (s *Server) put(project string, collection string, obj Object) string {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		s.nextID++
+		id = fmt.Sprintf("%s-%d", strings.TrimSuffix(collection, "s"), s.nextID)
+	}
+	obj = withID(collection, obj, id)
+
+	if collection == "metric_dashboards" {
+		attributes, _ := obj["attributes"].(map[string]interface{})
+		s.assignNestedIDs(attributes, "charts")
+		s.assignNestedIDs(attributes, "groups")
+		if groups, ok := attributes["groups"].([]interface{}); ok {
+			for _, g := range groups {
+				group, _ := g.(map[string]interface{})
+				s.assignNestedIDs(group, "charts")
+				s.assignNestedIDs(group, "panels")
+			}
+		}
+	}
+
+	key := collectionKey(project, collection)
+	if s.objects[key] == nil {
+		s.objects[key] = map[string]Object{}
+	}
+	s.objects[key][id] = obj
+	return id
+}
+
+func (s *Server) assignNestedIDs(parent map[string]interface{}, field string) {
+	items, _ := parent[field].([]interface{})
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, _ := m["id"].(string); id == "" {
+			s.nextID++
+			m["id"] = strconv.Itoa(s.nextID)
+		}
+	}
+}
+
+func (s *Server) roleBinding(project string, role string) client.RoleBinding {
+	if binding, ok := s.roleBindings[roleBindingKey(project, role)]; ok {
+		return binding
+	}
+	return client.RoleBinding{RoleName: role, ProjectName: project, Users: []string{}}
+}
+
+// withID sets the ID of obj. Event queries also carry their ID in their attributes.
+func withID(collection string, obj Object, id string) Object {
+	obj["id"] = id
+	if collection == "event_queries" {
+		attributes, _ := obj["attributes"].(map[string]interface{})
+		if attributes == nil {
+			attributes = map[string]interface{}{}
+			obj["attributes"] = attributes
+		}
+		attributes["id"] = id
+	}
+	return obj
+}
+
+// present returns obj as the API would send it back. Like the real API, ServiceNow
+// passwords and webhook signing secrets are never returned.
+func present(collection string, obj Object) Object {
+	obj = clone(obj)
+	if collection == "destinations" {
+		attributes, _ := obj["attributes"].(map[string]interface{})
+		if auth, ok := attributes["auth"].(map[string]interface{}); ok {
+			delete(auth, "password")
+		}
+		delete(attributes, "signing_secret")
+	}
+	return obj
+}
+
+func decodeData(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	var req struct {
+		Data Object `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return nil, false
+	}
+	if req.Data == nil {
+		writeError(w, http.StatusBadRequest, "request body has no data")
+		return nil, false
+	}
+	return req.Data, true
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []client.APIError{{
+			Status: strconv.Itoa(status),
+			Title:  http.StatusText(status),
+			Detail: detail,
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// clone deep copies obj through a JSON round trip, the way it would travel over the wire.
+func clone(obj Object) Object {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		panic(fmt.Sprintf("fake: object is not JSON serializable: %v", err))
+	}
+	var copied Object
+	if err := json.Unmarshal(bytes, &copied); err != nil {
+		panic(fmt.Sprintf("fake: failed to copy object: %v", err))
+	}
+	return copied
+}
+
+func collectionKey(project string, collection string) string {
+	return url.PathEscape(project) + "/" + collection
+}
+
+func roleBindingKey(project string, role string) string {
+	return project + "/" + role
+}
@@ -0,0 +1,181 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+	"github.com/lightstep/terraform-provider-lightstep/client/fake"
+)
+
+func TestServer_metricDashboards(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.PageSize = 1
+
+	ctx := context.Background()
+	c := server.NewClient()
+
+	created, err := c.CreateUnifiedDashboard(ctx, "p", client.UnifiedDashboard{
+		Type: "dashboard",
+		Attributes: client.UnifiedDashboardAttributes{
+			Name: "checkout",
+			Groups: []client.UnifiedGroup{{
+				Title:  "overview",
+				Charts: []client.UnifiedChart{{Title: "cpu", ChartType: "timeseries"}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	require.Len(t, created.Attributes.Groups, 1)
+	assert.NotEmpty(t, created.Attributes.Groups[0].ID)
+	assert.NotEmpty(t, created.Attributes.Groups[0].Charts[0].ID)
+
+	created.Attributes.Name = "checkout v2"
+	_, err = c.UpdateUnifiedDashboard(ctx, "p", created.ID, created.Attributes)
+	require.NoError(t, err)
+
+	_, err = c.CreateUnifiedDashboard(ctx, "p", client.UnifiedDashboard{Type: "dashboard", Attributes: client.UnifiedDashboardAttributes{Name: "other"}})
+	require.NoError(t, err)
+
+	dashboards, err := c.ListUnifiedDashboards(ctx, "p")
+	require.NoError(t, err)
+	require.Len(t, dashboards, 2)
+	assert.Equal(t, "checkout v2", dashboards[0].Attributes.Name)
+
+	require.NoError(t, c.DeleteUnifiedDashboard(ctx, "p", created.ID))
+	_, err = c.GetUnifiedDashboard(ctx, "p", created.ID)
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestServer_destinations_hide_passwords(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	c := server.NewClient()
+
+	dest, err := c.CreateDestination(ctx, "p", client.Destination{
+		Type: "destination",
+		Attributes: client.ServiceNowAttributes{
+			Name:            "sn",
+			DestinationType: "servicenow",
+			URL:             "https://example.service-now.com",
+			Auth:            client.Auth{Username: "admin", Password: "secret"},
+		},
+	})
+	require.NoError(t, err)
+
+	stored, ok := server.Get("p", "destinations", dest.ID)
+	require.True(t, ok)
+	assert.Equal(t, "secret", stored["attributes"].(map[string]interface{})["auth"].(map[string]interface{})["password"])
+
+	read, err := c.GetDestination(ctx, "p", dest.ID)
+	require.NoError(t, err)
+	auth := read.Attributes.(client.ServiceNowAttributes).Auth
+	assert.Equal(t, "admin", auth.Username)
+	assert.Empty(t, auth.Password)
+
+	secret := "hmac-key"
+	dest, err = c.CreateDestination(ctx, "p", client.Destination{
+		Type: "destination",
+		Attributes: client.WebhookAttributes{
+			Name:            "hook",
+			DestinationType: "webhook",
+			URL:             "https://example.com/hook",
+			SigningSecret:   &secret,
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, dest.Attributes.(client.WebhookAttributes).SigningSecret)
+
+	stored, ok = server.Get("p", "destinations", dest.ID)
+	require.True(t, ok)
+	assert.Equal(t, "hmac-key", stored["attributes"].(map[string]interface{})["signing_secret"])
+}
+
+func TestServer_eventQueries(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	c := server.NewClient()
+
+	created, err := c.CreateEventQuery(ctx, "p", client.EventQueryAttributes{Name: "deploys", QueryString: "logs"})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+
+	read, err := c.GetEventQuery(ctx, "p", created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "deploys", read.Name)
+}
+
+func TestServer_organizationSettings(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	c := server.NewClient()
+
+	binding, err := c.ListRoleBinding(ctx, "p", "project editor")
+	require.NoError(t, err)
+	assert.Empty(t, binding.Users)
+
+	_, err = c.UpdateRoleBinding(ctx, "p", "project editor", "b@example.com", "a@example.com")
+	require.NoError(t, err)
+	binding, err = c.ListRoleBinding(ctx, "p", "project editor")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, binding.Users)
+
+	mappings := client.SAMLGroupMappings{Mappings: []client.SAMLGroupMapping{{
+		SAMLAttributeKey:   "group",
+		SAMLAttributeValue: "sre",
+		OrganizationRole:   "organization viewer",
+	}}}
+	require.NoError(t, c.UpdateSAMLGroupMappings(ctx, mappings))
+	read, err := c.ListSAMLGroupMappings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, mappings, read)
+}
+
+func TestServer_validationEndpoints(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+	server.ValidateSnoozeRule = func(rule fake.Object) (bool, string) {
+		return false, "schedule is required"
+	}
+
+	ctx := context.Background()
+	c := server.NewClient()
+
+	valid, reason, err := c.ValidateSnoozeRule(ctx, "p", client.SnoozeRule{})
+	require.NoError(t, err)
+	assert.False(t, valid)
+	assert.Equal(t, "schedule is required", reason)
+
+	var resp struct {
+		Data struct {
+			Queries []map[string]string `json:"queries"`
+		} `json:"data"`
+	}
+	err = c.CallAPI(ctx, "POST", "projects/p/query_translation", map[string]interface{}{
+		"data": map[string]interface{}{
+			"queries": []client.MetricQueryWithAttributes{{Name: "a", QueryString: "metric cpu"}},
+		},
+	}, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"query-name": "a", "tql-query": "metric cpu"}}, resp.Data.Queries)
+}
+
+func TestServer_rejects_unknown_credentials(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	_, err := client.NewClient("wrong", server.Org, server.URL).ListStreams(context.Background(), "p")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid API key")
+}
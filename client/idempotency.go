@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-uuid"
+)
+
+// IdempotencyKeyHeader is sent on every POST so the API can recognize a create that is
+// retried after its response was lost, instead of creating the resource twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random key, falling back to no key at all if the system
+// cannot provide randomness.
+func newIdempotencyKey() string {
+	key, err := uuid.GenerateUUID()
+	if err != nil {
+		log.Printf("[WARN] failed to generate an idempotency key: %v", err)
+		return ""
+	}
+	return key
+}
+
+type duplicateCheckKey struct{}
+
+// duplicateCheck looks up a resource that a failed create may have produced anyway. It is
+// only consulted before retrying a create while the API has not shown that it honors
+// idempotency keys.
+type duplicateCheck struct {
+	find func(ctx context.Context) (map[string]json.RawMessage, error)
+	// sent is when the create was first sent. Only a resource created or updated since then
+	// can be the one a failed attempt produced.
+	sent  time.Time
+	found json.RawMessage
+}
+
+// withDuplicateCheck attaches find to the create issued with the returned context, which
+// must be sent right away. find returns the JSON:API resource objects of the resources
+// looking like the one being created, by ID. It makes no request by itself: find is only
+// called when a failed create is about to be retried.
+func (c *Client) withDuplicateCheck(
+	ctx context.Context,
+	find func(ctx context.Context) (map[string]json.RawMessage, error),
+) (context.Context, *duplicateCheck) {
+	check := &duplicateCheck{find: find, sent: time.Now()}
+	if c.idempotencySupported.Load() {
+		return ctx, check
+	}
+	return context.WithValue(ctx, duplicateCheckKey{}, check), check
+}
+
+// checkRetry wraps retryablehttp.DefaultRetryPolicy. Before a create is retried against an
+// API that does not echo idempotency keys back, it looks for a resource that matches the
+// create and was created after it was sent, and stops retrying when there is exactly one.
+func (c *Client) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if resp != nil && resp.Header.Get(IdempotencyKeyHeader) != "" {
+		c.idempotencySupported.Store(true)
+	}
+	if !retry || checkErr != nil || c.idempotencySupported.Load() {
+		return retry, checkErr
+	}
+
+	// only a lost response or a server error can hide a successful create, a 429 or
+	// other 4xx response means the request was not applied
+	if resp != nil && resp.StatusCode < http.StatusInternalServerError {
+		return retry, checkErr
+	}
+
+	check, ok := ctx.Value(duplicateCheckKey{}).(*duplicateCheck)
+	if !ok || check == nil {
+		return retry, checkErr
+	}
+
+	// the lookup must not run the check again when its own requests are retried
+	matches, findErr := check.find(context.WithValue(ctx, duplicateCheckKey{}, (*duplicateCheck)(nil)))
+	if findErr != nil {
+		log.Printf("[WARN] failed to look for a resource created by a failed request, retrying anyway: %v", findErr)
+		return retry, checkErr
+	}
+
+	// with several new matches, it is safer to retry than to adopt the wrong resource
+	var found json.RawMessage
+	for _, match := range matches {
+		if !createdSince(match, check.sent) {
+			continue
+		}
+		if found != nil {
+			return retry, checkErr
+		}
+		found = match
+	}
+	if found != nil {
+		check.found = found
+		return false, nil
+	}
+	return retry, checkErr
+}
+
+// createdSince reports whether the JSON:API resource object raw was created, or failing
+// that updated, at or after t. A resource without either time can't be told apart from
+// one that already existed, so it never is. The times come from the API's clock, so a
+// resource created within a clock skew before t may still be taken for a new one.
+func createdSince(raw json.RawMessage, t time.Time) bool {
+	var resource struct {
+		Attributes struct {
+			CreatedTime string `json:"created_time"`
+			UpdatedTime string `json:"updated_time"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(raw, &resource); err != nil {
+		return false
+	}
+	value := resource.Attributes.CreatedTime
+	if value == "" {
+		value = resource.Attributes.UpdatedTime
+	}
+	created, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return !created.Before(t.Truncate(time.Second))
+}
+
+// resolve returns the resource found by the duplicate check in place of the create's
+// response, or the create's own response and error if nothing was found.
+func (check *duplicateCheck) resolve(resp Envelope, err error) (Envelope, error) {
+	if check.found != nil {
+		return Envelope{Data: check.found}, nil
+	}
+	return resp, err
+}
+
+// findMatches marshals the items matching match, by ID.
+func findMatches[T any](items []T, id func(T) string, match func(T) bool) (map[string]json.RawMessage, error) {
+	matches := map[string]json.RawMessage{}
+	for _, item := range items {
+		if !match(item) {
+			continue
+		}
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		matches[id(item)] = raw
+	}
+	return matches, nil
+}
+
+// sameLabels reports whether a and b hold the same labels, in any order.
+func sameLabels(a []Label, b []Label) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[Label]int, len(a))
+	for _, l := range a {
+		counts[l]++
+	}
+	for _, l := range b {
+		if counts[l] == 0 {
+			return false
+		}
+		counts[l]--
+	}
+	return true
+}
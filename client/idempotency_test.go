@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyDashboardServer stores every dashboard it is asked to create, but fails the first
+// create with a 502 as if the response had been lost on the way back.
+type flakyDashboardServer struct {
+	t       *testing.T
+	echoKey bool
+	// firstStatus replaces the 502 returned for the first create
+	firstStatus int
+	// dropFirst fails the first create without storing the dashboard
+	dropFirst bool
+
+	mu         sync.Mutex
+	keys       []string
+	lists      int
+	dashboards []UnifiedDashboard
+}
+
+func (s *flakyDashboardServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.echoKey {
+		w.Header().Set(IdempotencyKeyHeader, r.Header.Get(IdempotencyKeyHeader))
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.lists++
+		body, err := json.Marshal(map[string]any{"data": s.dashboards})
+		require.NoError(s.t, err)
+		_, err = w.Write(body)
+		require.NoError(s.t, err)
+	case http.MethodPost:
+		s.keys = append(s.keys, r.Header.Get(IdempotencyKeyHeader))
+
+		var req struct {
+			Data UnifiedDashboard `json:"data"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		created := req.Data
+		created.ID = "dashboard-" + strconv.Itoa(len(s.dashboards))
+		created.Attributes.CreatedTime = time.Now().UTC().Format(time.RFC3339)
+
+		if len(s.keys) == 1 {
+			if !s.dropFirst {
+				s.dashboards = append(s.dashboards, created)
+			}
+			status := s.firstStatus
+			if status == 0 {
+				status = http.StatusBadGateway
+			}
+			w.WriteHeader(status)
+			return
+		}
+		s.dashboards = append(s.dashboards, created)
+		body, err := json.Marshal(map[string]any{"data": created})
+		require.NoError(s.t, err)
+		_, err = w.Write(body)
+		require.NoError(s.t, err)
+	}
+}
+
+func newTestDashboard() UnifiedDashboard {
+	return UnifiedDashboard{
+		Type: "dashboard",
+		Attributes: UnifiedDashboardAttributes{
+			Name:   "checkout",
+			Labels: []Label{{Key: "team", Value: "payments"}},
+		},
+	}
+}
+
+func Test_CreateUnifiedDashboard_reuses_idempotency_key_across_retries(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyDashboardServer{t: t, echoKey: true}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond))
+	_, err := c.CreateUnifiedDashboard(context.Background(), "tacoman", newTestDashboard())
+	require.NoError(t, err)
+
+	require.Len(t, handler.keys, 2)
+	assert.NotEmpty(t, handler.keys[0])
+	assert.Equal(t, handler.keys[0], handler.keys[1])
+	// the API honors the key, so there is no need to look for a duplicate
+	assert.Equal(t, 0, handler.lists)
+}
+
+func Test_CreateUnifiedDashboard_finds_dashboard_created_by_failed_request(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyDashboardServer{t: t}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond))
+	dashboard, err := c.CreateUnifiedDashboard(context.Background(), "tacoman", newTestDashboard())
+	require.NoError(t, err)
+
+	assert.Equal(t, "dashboard-0", dashboard.ID)
+	assert.Len(t, handler.keys, 1)
+	assert.Len(t, handler.dashboards, 1)
+	// only before retrying
+	assert.Equal(t, 1, handler.lists)
+}
+
+func Test_CreateUnifiedDashboard_ignores_existing_look_alike(t *testing.T) {
+	t.Parallel()
+
+	lookAlike := newTestDashboard()
+	lookAlike.ID = "dashboard-0"
+	lookAlike.Attributes.CreatedTime = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	handler := &flakyDashboardServer{t: t, dropFirst: true, dashboards: []UnifiedDashboard{lookAlike}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond))
+	dashboard, err := c.CreateUnifiedDashboard(context.Background(), "tacoman", newTestDashboard())
+	require.NoError(t, err)
+
+	// the dashboard that existed before isn't taken for the one the failed request created
+	assert.Equal(t, "dashboard-1", dashboard.ID)
+	assert.Len(t, handler.keys, 2)
+	assert.Len(t, handler.dashboards, 2)
+}
+
+func Test_CreateUnifiedDashboard_skips_duplicate_check_after_rate_limit(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyDashboardServer{t: t, firstStatus: http.StatusTooManyRequests, dropFirst: true}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond))
+	dashboard, err := c.CreateUnifiedDashboard(context.Background(), "tacoman", newTestDashboard())
+	require.NoError(t, err)
+
+	assert.Equal(t, "dashboard-0", dashboard.ID)
+	assert.Len(t, handler.keys, 2)
+	// a 429 wasn't applied, so there is nothing to look for
+	assert.Equal(t, 0, handler.lists)
+}
+
+func Test_CreateUnifiedDashboard_read_only_sends_nothing(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyDashboardServer{t: t}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithReadOnly(true))
+	_, err := c.CreateUnifiedDashboard(context.Background(), "tacoman", newTestDashboard())
+	assert.True(t, IsReadOnly(err), "%v", err)
+	assert.Empty(t, handler.keys)
+	assert.Equal(t, 0, handler.lists)
+}
+
+func Test_sameLabels(t *testing.T) {
+	t.Parallel()
+
+	a := []Label{{Key: "team", Value: "payments"}, {Value: "tier-1"}}
+	assert.True(t, sameLabels(a, []Label{{Value: "tier-1"}, {Key: "team", Value: "payments"}}))
+	assert.False(t, sameLabels(a, []Label{{Key: "team", Value: "payments"}}))
+	assert.False(t, sameLabels(a, []Label{{Key: "team", Value: "payments"}, {Value: "tier-2"}}))
+}
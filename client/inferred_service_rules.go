@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -89,6 +88,16 @@ func (c *Client) GetInferredServiceRule(
 	return inferredServiceRuleResponse, err
 }
 
+// IterateInferredServiceRules returns an iterator over every inferred service rule in the project.
+func (c *Client) IterateInferredServiceRules(projectName string) *Iterator[InferredServiceRuleResponse] {
+	return newIterator[InferredServiceRuleResponse](c, getInferredServiceRuleUrl(projectName))
+}
+
+// ListInferredServiceRules returns every inferred service rule in the project, following pagination links.
+func (c *Client) ListInferredServiceRules(ctx context.Context, projectName string) ([]InferredServiceRuleResponse, error) {
+	return c.IterateInferredServiceRules(projectName).All(ctx)
+}
+
 func (c *Client) UpdateInferredServiceRule(
 	ctx context.Context,
 	projectName string,
@@ -126,11 +135,8 @@ func (c *Client) DeleteInferredServiceRule(
 ) error {
 	apiPath := getInferredServiceRuleUrlWithId(projectName, inferredServiceRuleID)
 	err := c.CallAPI(ctx, "DELETE", apiPath, nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 
 	return nil
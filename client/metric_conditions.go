@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type UnifiedCondition struct {
@@ -23,6 +22,9 @@ type UnifiedConditionAttributes struct {
 	Queries        []MetricQueryWithAttributes `json:"metric-queries"`
 	AlertingRules  []AlertingRule              `json:"alerting-rules,omitempty"`
 	CompositeAlert *CompositeAlert             `json:"composite-alert,omitempty"`
+	// CreatedTime and UpdatedTime are RFC 3339 timestamps set by the API
+	CreatedTime string `json:"created_time,omitempty"`
+	UpdatedTime string `json:"updated_time,omitempty"`
 }
 
 type CompositeAlert struct {
@@ -143,7 +145,19 @@ func (c *Client) CreateUnifiedCondition(
 
 	url := getURL(projectName, "")
 
+	ctx, check := c.withDuplicateCheck(ctx, func(ctx context.Context) (map[string]json.RawMessage, error) {
+		conditions, err := c.ListUnifiedConditions(ctx, projectName)
+		if err != nil {
+			return nil, err
+		}
+		return findMatches(conditions, func(cond UnifiedCondition) string { return cond.ID }, func(cond UnifiedCondition) bool {
+			return cond.Attributes.Name == condition.Attributes.Name &&
+				cond.Attributes.Description == condition.Attributes.Description &&
+				sameLabels(cond.Attributes.Labels, condition.Attributes.Labels)
+		})
+	})
 	err = c.CallAPI(ctx, "POST", url, Envelope{Data: bytes}, &resp)
+	resp, err = check.resolve(resp, err)
 	if err != nil {
 		return cond, err
 	}
@@ -209,15 +223,22 @@ func (c *Client) GetUnifiedCondition(ctx context.Context, projectName string, co
 	return &cond, err
 }
 
+// IterateUnifiedConditions returns an iterator over every alert in the project.
+func (c *Client) IterateUnifiedConditions(projectName string) *Iterator[UnifiedCondition] {
+	return newIterator[UnifiedCondition](c, getURL(projectName, ""))
+}
+
+// ListUnifiedConditions returns every alert in the project, following pagination links.
+func (c *Client) ListUnifiedConditions(ctx context.Context, projectName string) ([]UnifiedCondition, error) {
+	return c.IterateUnifiedConditions(projectName).All(ctx)
+}
+
 func (c *Client) DeleteUnifiedCondition(ctx context.Context, projectName string, conditionID string) error {
 	url := getURL(projectName, conditionID)
 
 	err := c.CallAPI(ctx, "DELETE", url, nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -23,6 +22,9 @@ type UnifiedDashboardAttributes struct {
 	TemplateVariables []TemplateVariable `json:"template_variables"`
 	EventQueryIDs     []string           `json:"event_query_ids"`
 	WorkflowLinks     []WorkflowLink     `json:"workflow_links"`
+	// CreatedTime and UpdatedTime are RFC 3339 timestamps set by the API
+	CreatedTime string `json:"created_time,omitempty"`
+	UpdatedTime string `json:"updated_time,omitempty"`
 }
 
 type UnifiedGroup struct {
@@ -141,7 +143,19 @@ func (c *Client) CreateUnifiedDashboard(
 
 	url := getUnifiedDashboardURL(projectName, "")
 
+	ctx, check := c.withDuplicateCheck(ctx, func(ctx context.Context) (map[string]json.RawMessage, error) {
+		dashboards, err := c.ListUnifiedDashboards(ctx, projectName)
+		if err != nil {
+			return nil, err
+		}
+		return findMatches(dashboards, func(d UnifiedDashboard) string { return d.ID }, func(d UnifiedDashboard) bool {
+			return d.Attributes.Name == dashboard.Attributes.Name &&
+				d.Attributes.Description == dashboard.Attributes.Description &&
+				sameLabels(d.Attributes.Labels, dashboard.Attributes.Labels)
+		})
+	})
 	err = c.CallAPI(ctx, "POST", url, Envelope{Data: bytes}, &resp)
+	resp, err = check.resolve(resp, err)
 	if err != nil {
 		return cond, err
 	}
@@ -169,6 +183,16 @@ func (c *Client) GetUnifiedDashboard(ctx context.Context, projectName string, id
 	return d, err
 }
 
+// IterateUnifiedDashboards returns an iterator over every dashboard in the project.
+func (c *Client) IterateUnifiedDashboards(projectName string) *Iterator[UnifiedDashboard] {
+	return newIterator[UnifiedDashboard](c, getUnifiedDashboardURL(projectName, ""))
+}
+
+// ListUnifiedDashboards returns every dashboard in the project, following pagination links.
+func (c *Client) ListUnifiedDashboards(ctx context.Context, projectName string) ([]UnifiedDashboard, error) {
+	return c.IterateUnifiedDashboards(projectName).All(ctx)
+}
+
 func (c *Client) UpdateUnifiedDashboard(
 	ctx context.Context,
 	projectName string,
@@ -203,11 +227,8 @@ func (c *Client) DeleteUnifiedDashboard(ctx context.Context, projectName string,
 	url := getUnifiedDashboardURL(projectName, dashboardID)
 
 	err := c.CallAPI(ctx, "DELETE", url, nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
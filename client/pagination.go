@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// PageLinks holds the JSON:API pagination links returned alongside a collection
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+}
+
+// listEnvelope represents a page of a collection returned by the Lightstep Public API
+type listEnvelope struct {
+	Data  json.RawMessage `json:"data"`
+	Links *PageLinks      `json:"links,omitempty"`
+}
+
+// Iterator walks every item of a collection, transparently requesting the next page
+// from the API whenever the current one is exhausted.
+//
+//	it := c.IterateUnifiedDashboards("my-project")
+//	for it.Next(ctx) {
+//		dashboard := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	c       *Client
+	nextURL string
+	decode  func(json.RawMessage) ([]T, error)
+
+	page    []T
+	current T
+	err     error
+}
+
+func newIterator[T any](c *Client, suffix string) *Iterator[T] {
+	return newConvertingIterator(c, suffix, func(item T) T { return item })
+}
+
+// newConvertingIterator builds an iterator for collections whose wire representation (W)
+// differs from the type handed back to callers (T).
+func newConvertingIterator[W any, T any](c *Client, suffix string, convert func(W) T) *Iterator[T] {
+	return &Iterator[T]{
+		c:       c,
+		nextURL: fmt.Sprintf("%v/%v", c.baseUrl, suffix),
+		decode: func(data json.RawMessage) ([]T, error) {
+			var wire []W
+			if err := json.Unmarshal(data, &wire); err != nil {
+				return nil, err
+			}
+			items := make([]T, 0, len(wire))
+			for _, w := range wire {
+				items = append(items, convert(w))
+			}
+			return items, nil
+		},
+	}
+}
+
+// Next advances the iterator to the next item, fetching a new page when needed.
+// It returns false once the collection is exhausted or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.nextURL == "" {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the item the iterator is currently positioned on.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the first error encountered while paging through the collection.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns every remaining item.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) error {
+	var resp listEnvelope
+
+	err := callAPI(ctx, it.c, it.nextURL, "GET", it.c.defaultHeaders(), nil, &resp)
+	if err != nil {
+		return err
+	}
+
+	it.page, err = it.decode(resp.Data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response json: %v", err)
+	}
+
+	it.nextURL = ""
+	if resp.Links != nil && resp.Links.Next != "" {
+		it.nextURL, err = it.c.resolveLink(resp.Links.Next)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLink turns a pagination link, which may be absolute, host-relative or relative
+// to the organization, into a fully qualified URL. Links to another scheme or host are
+// refused, since the next page is requested with the API key.
+func (c *Client) resolveLink(link string) (string, error) {
+	base, err := url.Parse(c.baseUrl + "/")
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid pagination link %q: %v", link, err)
+	}
+
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != base.Scheme || resolved.Host != base.Host {
+		return "", fmt.Errorf("refusing to follow pagination link %q outside of %s://%s", link, base.Scheme, base.Host)
+	}
+	return resolved.String(), nil
+}
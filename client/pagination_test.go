@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListUnifiedDashboards_follows_pagination_links(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/public/v0.2/blars/projects/tacoman/metric_dashboards", r.URL.Path)
+
+		var body map[string]any
+		switch r.URL.Query().Get("page") {
+		case "":
+			body = map[string]any{
+				"data": []UnifiedDashboard{
+					{Type: "dashboard", ID: "1"},
+					{Type: "dashboard", ID: "2"},
+				},
+				// relative to the organization
+				"links": map[string]any{"next": "projects/tacoman/metric_dashboards?page=2"},
+			}
+		case "2":
+			body = map[string]any{
+				"data": []UnifiedDashboard{},
+				// absolute
+				"links": map[string]any{"next": server.URL + "/public/v0.2/blars/projects/tacoman/metric_dashboards?page=3"},
+			}
+		case "3":
+			body = map[string]any{
+				"data": []UnifiedDashboard{{Type: "dashboard", ID: "3"}},
+			}
+		default:
+			t.Errorf("unexpected page requested: %v", r.URL)
+		}
+
+		resp, err := json.Marshal(body)
+		require.NoError(t, err)
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	dashboards, err := c.ListUnifiedDashboards(context.Background(), "tacoman")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, d := range dashboards {
+		ids = append(ids, d.ID)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func Test_IterateEventQueries_stops_on_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		resp, err := json.Marshal(map[string]any{
+			"data": []WireEventQueryAttributes{
+				{Attributes: EventQueryAttributes{ID: "eq-1", Name: "deploys"}},
+			},
+			"links": map[string]any{"next": "/public/v0.2/blars/projects/tacoman/event_queries?page=2"},
+		})
+		require.NoError(t, err)
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	it := c.IterateEventQueries("tacoman")
+
+	require.True(t, it.Next(context.Background()))
+	assert.Equal(t, "deploys", it.Value().Name)
+
+	assert.False(t, it.Next(context.Background()))
+	apiErr, ok := it.Err().(APIClientError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusForbidden, apiErr.GetStatusCode())
+}
+
+func Test_ListUnifiedDashboards_refuses_links_to_other_hosts(t *testing.T) {
+	var leaked bool
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("Authorization") != ""
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := json.Marshal(map[string]any{
+			"data":  []UnifiedDashboard{{Type: "dashboard", ID: "1"}},
+			"links": map[string]any{"next": other.URL + "/public/v0.2/blars/projects/tacoman/metric_dashboards?page=2"},
+		})
+		require.NoError(t, err)
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL)
+	_, err := c.ListUnifiedDashboards(context.Background(), "tacoman")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refusing to follow pagination link")
+	assert.False(t, leaked)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// permissionCheckID is a stream ID that never exists. Deleting it tells whether the API key
+// may write to a project without changing anything.
+const permissionCheckID = "terraform-provider-permission-check"
+
+type Project struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type,omitempty"`
+	Attributes ProjectAttributes `json:"attributes"`
+}
+
+type ProjectAttributes struct {
+	Name string `json:"name"`
+}
+
+// IterateProjects returns an iterator over every project in the organization.
+func (c *Client) IterateProjects() *Iterator[Project] {
+	return newIterator[Project](c, "projects")
+}
+
+// ListProjects returns every project in the organization, following pagination links.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	return c.IterateProjects().All(ctx)
+}
+
+// CheckCredentials makes a cheap authenticated request: it fetches the first page of projects.
+// Use IsUnauthorized, IsForbidden and IsNotFound to tell an invalid API key from an unknown
+// organization. The first project is returned, or nil when the organization has none.
+func (c *Client) CheckCredentials(ctx context.Context) (*Project, error) {
+	it := c.IterateProjects()
+	if !it.Next(ctx) {
+		return nil, it.Err()
+	}
+	project := it.Value()
+	return &project, nil
+}
+
+// CheckWritePermission reports whether the API key may modify resources in the project. The
+// API has no endpoint to query permissions, so it sends a real DELETE request for a stream that
+// does not exist, which the API refuses with a 403 for read-only keys. Nothing is changed, but
+// the request is audited like any other write; only call it when the user asked for the check.
+func (c *Client) CheckWritePermission(ctx context.Context, projectName string) error {
+	err := c.CallAPI(ctx, "DELETE", fmt.Sprintf("projects/%v/streams/%v", projectName, permissionCheckID), nil, nil)
+	if IsForbidden(err) || IsUnauthorized(err) {
+		return err
+	}
+	// anything else, typically a 404, means the request got past authorization
+	return nil
+}
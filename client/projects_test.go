@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func Test_CheckCredentials(t *testing.T) {
+	status := http.StatusOK
+	body := `{"data": [{"id": "p1", "attributes": {"name": "p1"}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/public/v0.2/blars/projects", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	c := NewClient("api", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+
+	project, err := c.CheckCredentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "p1", project.ID)
+
+	body = `{"data": []}`
+	project, err = c.CheckCredentials(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, project)
+
+	status, body = http.StatusUnauthorized, `{"errors": ["invalid API key"]}`
+	_, err = c.CheckCredentials(context.Background())
+	assert.True(t, IsUnauthorized(err), "%v", err)
+
+	status, body = http.StatusNotFound, `{"errors": ["unknown organization"]}`
+	_, err = c.CheckCredentials(context.Background())
+	assert.True(t, IsNotFound(err), "%v", err)
+}
+
+func Test_CheckWritePermission(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/public/v0.2/blars/projects/p1/streams/"+permissionCheckID, r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"errors": ["nope"]}`))
+	}))
+	defer server.Close()
+	c := NewClient("api", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+
+	require.NoError(t, c.CheckWritePermission(context.Background(), "p1"))
+
+	status = http.StatusForbidden
+	err := c.CheckWritePermission(context.Background(), "p1")
+	assert.True(t, IsForbidden(err), "%v", err)
+}
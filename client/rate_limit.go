@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minRateLimitPerSecond is the floor the adaptive limiter never throttles below.
+	minRateLimitPerSecond = 0.1
+
+	// epochThreshold separates X-RateLimit-Reset values expressed as a unix timestamp
+	// from values expressed as a number of seconds until the window resets.
+	epochThreshold = 1_000_000_000
+)
+
+// adaptiveLimiter wraps the client wide rate.Limiter and adjusts it from the rate-limit
+// headers returned by the API: the limit is halved on every 429, pinned to the remaining
+// budget when X-RateLimit-* headers are present and slowly recovers towards the configured
+// limit otherwise. Retry-After pauses every request sharing the limiter.
+type adaptiveLimiter struct {
+	limiter   *rate.Limiter
+	baseLimit rate.Limit
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newAdaptiveLimiter(limit rate.Limit) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter:   rate.NewLimiter(limit, 1),
+		baseLimit: limit,
+	}
+}
+
+// Limit returns the rate currently enforced by the limiter.
+func (l *adaptiveLimiter) Limit() rate.Limit {
+	return l.limiter.Limit()
+}
+
+// Wait blocks until the API asked us to resume (if it did) and a token is available.
+func (l *adaptiveLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// observe adjusts the limiter from a response returned by the API.
+func (l *adaptiveLimiter) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait := retryAfter(resp, now); wait > 0 {
+		if until := now.Add(wait); until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.setLimit(l.limiter.Limit() / 2)
+		return
+	}
+
+	remaining, reset, ok := rateLimitBudget(resp, now)
+	if ok {
+		if remaining <= 0 {
+			if until := now.Add(reset); until.After(l.pausedUntil) {
+				l.pausedUntil = until
+			}
+			return
+		}
+		if reset > 0 {
+			l.setLimit(rate.Limit(float64(remaining) / reset.Seconds()))
+			return
+		}
+	}
+
+	// additive recovery towards the configured limit
+	l.setLimit(l.limiter.Limit() + l.baseLimit/10)
+}
+
+// setLimit clamps limit between minRateLimitPerSecond and the configured limit.
+// Callers must hold l.mu.
+func (l *adaptiveLimiter) setLimit(limit rate.Limit) {
+	if limit > l.baseLimit {
+		limit = l.baseLimit
+	}
+	if limit < minRateLimitPerSecond {
+		limit = minRateLimitPerSecond
+	}
+	if limit != l.limiter.Limit() {
+		l.limiter.SetLimit(limit)
+	}
+}
+
+// retryAfter parses the Retry-After header, which holds either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// rateLimitBudget reads the X-RateLimit-Remaining and X-RateLimit-Reset headers. The reset
+// is accepted both as a unix timestamp and as a number of seconds from now.
+func rateLimitBudget(resp *http.Response, now time.Time) (remaining int, reset time.Duration, ok bool) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	value, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Reset"), 64)
+	if err != nil || value < 0 {
+		return remaining, 0, true
+	}
+	if value >= epochThreshold {
+		reset = time.Unix(int64(value), 0).Sub(now)
+	} else {
+		reset = time.Duration(value * float64(time.Second))
+	}
+	if reset < 0 {
+		reset = 0
+	}
+	return remaining, reset, true
+}
+
+// backoff is a retryablehttp.Backoff honoring Retry-After and X-RateLimit-Reset. Otherwise it
+// waits min * 2^attempt, capped at max, with jitter so concurrent applies don't retry in lockstep.
+func backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil {
+		now := time.Now()
+		if wait := retryAfter(resp, now); wait > 0 {
+			return wait
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if remaining, reset, ok := rateLimitBudget(resp, now); ok && remaining <= 0 && reset > 0 {
+				return reset
+			}
+		}
+	}
+
+	mult := math.Pow(2, float64(attemptNum)) * float64(min)
+	wait := time.Duration(mult)
+	if float64(wait) != mult || wait > max {
+		wait = max
+	}
+
+	// pick a random wait in [wait/2, wait], never going below min
+	half := wait / 2
+	if half > 0 {
+		wait = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+	if wait < min {
+		wait = min
+	}
+	return wait
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func responseWithHeaders(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func Test_backoff(t *testing.T) {
+	t.Parallel()
+
+	// Retry-After wins over the configured bounds
+	wait := backoff(time.Second, 2*time.Second, 0, responseWithHeaders(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"}))
+	assert.Equal(t, 5*time.Second, wait)
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	wait = backoff(time.Second, 2*time.Second, 0, responseWithHeaders(http.StatusServiceUnavailable, map[string]string{"Retry-After": date}))
+	assert.InDelta(t, 10*time.Second, wait, float64(2*time.Second))
+
+	wait = backoff(time.Second, time.Minute, 0, responseWithHeaders(http.StatusTooManyRequests, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     "7",
+	}))
+	assert.Equal(t, 7*time.Second, wait)
+
+	// exponential with jitter, bounded by min and max
+	for attempt := 0; attempt < 10; attempt++ {
+		wait = backoff(time.Second, 8*time.Second, attempt, responseWithHeaders(http.StatusBadGateway, nil))
+		expected := time.Second << attempt
+		if expected > 8*time.Second {
+			expected = 8 * time.Second
+		}
+		assert.GreaterOrEqual(t, wait, time.Second)
+		assert.GreaterOrEqual(t, wait, expected/2)
+		assert.LessOrEqual(t, wait, expected)
+	}
+}
+
+func Test_adaptiveLimiter_observe(t *testing.T) {
+	t.Parallel()
+
+	l := newAdaptiveLimiter(rate.Limit(4))
+
+	l.observe(responseWithHeaders(http.StatusTooManyRequests, nil))
+	assert.Equal(t, rate.Limit(2), l.Limit())
+	l.observe(responseWithHeaders(http.StatusTooManyRequests, nil))
+	assert.Equal(t, rate.Limit(1), l.Limit())
+
+	// recovers slowly but never beyond the configured limit
+	l.observe(responseWithHeaders(http.StatusOK, nil))
+	assert.InDelta(t, 1.4, float64(l.Limit()), 0.001)
+	for i := 0; i < 20; i++ {
+		l.observe(responseWithHeaders(http.StatusOK, nil))
+	}
+	assert.Equal(t, rate.Limit(4), l.Limit())
+
+	// pinned to the remaining budget
+	l.observe(responseWithHeaders(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "10",
+		"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(20*time.Second).Unix(), 10),
+	}))
+	assert.InDelta(t, 0.5, float64(l.Limit()), 0.05)
+
+	// never throttled to a standstill
+	for i := 0; i < 10; i++ {
+		l.observe(responseWithHeaders(http.StatusTooManyRequests, nil))
+	}
+	assert.Equal(t, rate.Limit(minRateLimitPerSecond), l.Limit())
+}
+
+func Test_adaptiveLimiter_pauses_on_retry_after(t *testing.T) {
+	t.Parallel()
+
+	l := newAdaptiveLimiter(rate.Inf)
+	l.observe(responseWithHeaders(http.StatusTooManyRequests, map[string]string{"Retry-After": "1"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
+
+func Test_CallAPI_retries_after_rate_limit(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0.2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, err := w.Write([]byte(`{"data": {"id": "abc"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(10*time.Millisecond, 50*time.Millisecond))
+
+	start := time.Now()
+	var resp Envelope
+	require.NoError(t, c.CallAPI(context.Background(), "GET", "projects/tacoman/metric_dashboards/abc", nil, &resp))
+	assert.Equal(t, 2, attempts)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func Test_CallAPI_gives_up_after_retry_max(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryMax(2), WithRetryWait(time.Millisecond, 5*time.Millisecond))
+
+	err := c.CallAPI(context.Background(), "GET", "projects/tacoman/metric_dashboards/abc", nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func Test_CallAPI_reports_rate_limit_after_retry_max(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, err := w.Write([]byte(`{"errors": [{"detail": "slow down"}]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryMax(1), WithRetryWait(time.Millisecond, 5*time.Millisecond), WithRateLimit(rate.Inf))
+
+	err := c.CallAPI(context.Background(), "GET", "projects/tacoman/metric_dashboards/abc", nil, nil)
+	require.Error(t, err)
+	assert.True(t, IsRateLimited(err), "%v", err)
+	assert.Contains(t, err.Error(), "slow down")
+}
+
+func Test_CallAPI_throttles_retries(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, err := w.Write([]byte(`{"data": {"id": "abc"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond), WithRateLimit(rate.Limit(4)))
+
+	// the 429 halves the limit to 2 per second, so the retry waits about half a second for
+	// a token while the backoff alone would only wait a few milliseconds
+	start := time.Now()
+	require.NoError(t, c.CallAPI(context.Background(), "GET", "projects/tacoman/metric_dashboards/abc", nil, nil))
+	assert.Equal(t, 2, attempts)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// readOnlyPOSTPattern matches the POST endpoints that only compute a result, and are
+// therefore still allowed when the client is read-only.
+var readOnlyPOSTPattern = regexp.MustCompile(`^projects/[^/]+/(snooze_rules_validate|query_translation)$`)
+
+// ReadOnlyError is returned for requests that would change state while the client is read-only.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("refusing to %s %s: read-only mode is enabled", e.Method, e.Path)
+}
+
+// IsReadOnly reports whether err was caused by a request refused in read-only mode.
+func IsReadOnly(err error) bool {
+	var readOnlyErr *ReadOnlyError
+	return errors.As(err, &readOnlyErr)
+}
+
+// WithReadOnly makes CallAPI refuse every request that could change state, so that e.g.
+// `terraform plan` can run with production credentials without any risk of writes.
+func WithReadOnly(readOnly bool) Option {
+	return func(c *Client) {
+		c.readOnly = readOnly
+	}
+}
+
+// ReadOnly reports whether the client refuses requests that could change state.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns a *ReadOnlyError if the client is read-only and the request may change state.
+func (c *Client) checkReadOnly(httpMethod string, suffix string) error {
+	if !c.readOnly {
+		return nil
+	}
+
+	switch strings.ToUpper(httpMethod) {
+	case "GET", "HEAD", "OPTIONS":
+		return nil
+	case "POST":
+		path, _, _ := strings.Cut(suffix, "?")
+		if readOnlyPOSTPattern.MatchString(path) {
+			return nil
+		}
+	}
+	return &ReadOnlyError{Method: httpMethod, Path: suffix}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func Test_CallAPI_read_only(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithReadOnly(true), WithRateLimit(rate.Inf))
+	require.True(t, c.ReadOnly())
+	ctx := context.Background()
+
+	require.NoError(t, c.CallAPI(ctx, "GET", "projects/p/streams/1", nil, nil))
+	require.NoError(t, c.CallAPI(ctx, "POST", "projects/p/snooze_rules_validate", map[string]string{}, nil))
+	require.NoError(t, c.CallAPI(ctx, "POST", "projects/p/query_translation", map[string]string{}, nil))
+
+	for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+		err := c.CallAPI(ctx, method, "projects/p/streams", map[string]string{}, nil)
+		require.Error(t, err, method)
+		assert.True(t, IsReadOnly(err), method)
+		assert.Contains(t, err.Error(), "read-only mode is enabled")
+	}
+
+	// the allowlist matches whole paths only
+	err := c.CallAPI(ctx, "POST", "projects/p/snooze_rules_validate/extra", map[string]string{}, nil)
+	assert.True(t, IsReadOnly(err))
+
+	assert.Equal(t, []string{
+		"GET /public/v0.2/blars/projects/p/streams/1",
+		"POST /public/v0.2/blars/projects/p/snooze_rules_validate",
+		"POST /public/v0.2/blars/projects/p/query_translation",
+	}, requests)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type SnoozeRuleWithID struct {
@@ -161,15 +160,22 @@ func (c *Client) GetSnoozeRule(ctx context.Context, projectName string, conditio
 	return &respRule, err
 }
 
+// IterateSnoozeRules returns an iterator over every snooze rule in the project.
+func (c *Client) IterateSnoozeRules(projectName string) *Iterator[SnoozeRuleWithID] {
+	return newIterator[SnoozeRuleWithID](c, getSnoozeRuleURL(projectName, ""))
+}
+
+// ListSnoozeRules returns every snooze rule in the project, following pagination links.
+func (c *Client) ListSnoozeRules(ctx context.Context, projectName string) ([]SnoozeRuleWithID, error) {
+	return c.IterateSnoozeRules(projectName).All(ctx)
+}
+
 func (c *Client) DeleteSnoozeRule(ctx context.Context, projectName string, conditionID string) error {
 	url := getSnoozeRuleURL(projectName, conditionID)
 
 	err := c.CallAPI(ctx, "DELETE", url, nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
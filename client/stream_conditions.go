@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type StreamCondition struct {
@@ -137,11 +136,8 @@ func (c *Client) GetStreamCondition(ctx context.Context, projectName string, con
 
 func (c *Client) DeleteStreamCondition(ctx context.Context, projectName string, conditionID string) error {
 	err := c.CallAPI(ctx, "DELETE", fmt.Sprintf("projects/%v/conditions/%v", projectName, conditionID), nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type Dashboard struct {
@@ -113,11 +112,8 @@ func (c *Client) GetDashboard(ctx context.Context, projectName string, dashboard
 
 func (c *Client) DeleteDashboard(ctx context.Context, projectName string, dashboardID string) error {
 	err := c.CallAPI(ctx, "DELETE", fmt.Sprintf("projects/%v/dashboards/%v", projectName, dashboardID), nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
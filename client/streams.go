@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 type Stream struct {
@@ -111,21 +110,14 @@ func (c *Client) CreateStream(
 	return s, err
 }
 
-func (c *Client) ListStreams(ctx context.Context, projectName string) ([]Stream, error) {
-	var (
-		s    []Stream
-		resp Envelope
-	)
+// IterateStreams returns an iterator over every stream in the project.
+func (c *Client) IterateStreams(projectName string) *Iterator[Stream] {
+	return newIterator[Stream](c, fmt.Sprintf("projects/%v/streams", projectName))
+}
 
-	err := c.CallAPI(ctx, "GET", fmt.Sprintf("projects/%v/streams", projectName), nil, &resp)
-	if err != nil {
-		return s, err
-	}
-	err = json.Unmarshal(resp.Data, &s)
-	if err != nil {
-		return s, err
-	}
-	return s, err
+// ListStreams returns every stream in the project, following pagination links.
+func (c *Client) ListStreams(ctx context.Context, projectName string) ([]Stream, error) {
+	return c.IterateStreams(projectName).All(ctx)
 }
 
 func (c *Client) GetStream(ctx context.Context, projectName string, StreamID string) (*Stream, error) {
@@ -172,11 +164,8 @@ func (c *Client) UpdateStream(ctx context.Context, projectName string,
 
 func (c *Client) DeleteStream(ctx context.Context, projectName string, StreamID string) error {
 	err := c.CallAPI(ctx, "DELETE", fmt.Sprintf("projects/%v/streams/%v", projectName, StreamID), nil, nil)
-	if err != nil {
-		apiClientError, ok := err.(APIResponseCarrier)
-		if !ok || apiClientError.GetStatusCode() != http.StatusNoContent {
-			return err
-		}
+	if err != nil && !isNoContent(err) {
+		return err
 	}
 	return nil
 }
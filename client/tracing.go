@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/lightstep/terraform-provider-lightstep/client"
+
+const (
+	// ProjectKey is the span attribute holding the Lightstep project a request or resource belongs to
+	ProjectKey = attribute.Key("lightstep.project")
+	// RetryCountKey is the span attribute holding how many times a request was retried
+	RetryCountKey = attribute.Key("lightstep.retry_count")
+)
+
+var projectPattern = regexp.MustCompile(`/projects/([^/?]+)`)
+
+type attemptsKey struct{}
+
+// startAPISpan starts the span covering a single API call, including all of its retries.
+// Spans are recorded by the global TracerProvider, so nothing is exported unless the
+// provider configured one.
+func startAPISpan(ctx context.Context, method string, url string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPMethod(method),
+		semconv.HTTPURL(url),
+	}
+	if m := projectPattern.FindStringSubmatch(url); m != nil {
+		attrs = append(attrs, ProjectKey.String(m[1]))
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "CallAPI "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return context.WithValue(ctx, attemptsKey{}, new(int64)), span
+}
+
+// endAPISpan records the outcome of the call on span and ends it.
+func endAPISpan(ctx context.Context, span trace.Span, err error) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int64); ok && atomic.LoadInt64(attempts) > 0 {
+		span.SetAttributes(RetryCountKey.Int64(atomic.LoadInt64(attempts) - 1))
+	}
+
+	status := http.StatusOK
+	if err != nil {
+		status = statusCode(err)
+	}
+	if status > 0 {
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+	}
+
+	// DELETE endpoints signal success with an empty 204 response
+	if err != nil && !isNoContent(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// countAttempt is a retryablehttp.RequestLogHook counting the attempts made for the request's span.
+func countAttempt(_ retryablehttp.Logger, req *http.Request, _ int) {
+	if attempts, ok := req.Context().Value(attemptsKey{}).(*int64); ok {
+		atomic.AddInt64(attempts, 1)
+	}
+}
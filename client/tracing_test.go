@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func useInMemoryTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func Test_CallAPI_records_spans(t *testing.T) {
+	exporter := useInMemoryTracing(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Method == http.MethodGet && attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"data": {}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryWait(time.Millisecond, 5*time.Millisecond))
+	require.NoError(t, c.CallAPI(context.Background(), "GET", "projects/tacoman/metric_dashboards/abc", nil, nil))
+	require.Error(t, c.CallAPI(context.Background(), "DELETE", "projects/tacoman/metric_dashboards/abc", nil, nil))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "CallAPI GET", spans[0].Name)
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "tacoman", attrs[ProjectKey].AsString())
+	assert.Equal(t, int64(http.StatusOK), attrs["http.status_code"].AsInt64())
+	assert.Equal(t, int64(1), attrs[RetryCountKey].AsInt64())
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, "CallAPI DELETE", spans[1].Name)
+	attrs = spanAttributes(spans[1])
+	assert.Equal(t, int64(http.StatusNotFound), attrs["http.status_code"].AsInt64())
+	assert.Equal(t, int64(0), attrs[RetryCountKey].AsInt64())
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// TransportConfig describes how to reach the API through proxies and TLS-intercepting
+// middleboxes. The zero value behaves like the default transport.
+type TransportConfig struct {
+	// ProxyURL overrides the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+	// CACertPEM holds PEM encoded certificates trusted in addition to the system roots.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded certificate and key presented for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables verification of the API's certificate. Only meant for debugging.
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns a pooled transport configured according to cfg.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // nolint: gosec
+	}
+
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, fmt.Errorf("no valid PEM encoded certificate found in the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0 {
+		if len(cfg.ClientCertPEM) == 0 || len(cfg.ClientKeyPEM) == 0 {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+		}
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// WithHTTPClient replaces the http.Client used to reach the API. Retries and rate limiting
+// still apply on top of it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.client.HTTPClient = httpClient
+	}
+}
+
+// WithTransport replaces the http.RoundTripper used to reach the API, e.g. one returned by
+// NewTransport, or one recording or replaying traffic in tests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.client.HTTPClient.Transport = transport
+	}
+}
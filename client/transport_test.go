@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func Test_NewTransport_trusts_custom_ca(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"id": "1"}}`))
+	}))
+	defer server.Close()
+
+	// the test server's certificate is not signed by a system root
+	c := NewClient("api", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+	err := c.CallAPI(context.Background(), "GET", "projects/p/streams/1", nil, nil)
+	require.Error(t, err)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := NewTransport(TransportConfig{CACertPEM: caPEM})
+	require.NoError(t, err)
+
+	c = NewClient("api", "blars", server.URL, WithTransport(transport), WithRetryMax(0), WithRateLimit(rate.Inf))
+	err = c.CallAPI(context.Background(), "GET", "projects/p/streams/1", nil, nil)
+	require.NoError(t, err)
+
+	transport, err = NewTransport(TransportConfig{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	c = NewClient("api", "blars", server.URL, WithHTTPClient(&http.Client{Transport: transport}), WithRetryMax(0), WithRateLimit(rate.Inf))
+	err = c.CallAPI(context.Background(), "GET", "projects/p/streams/1", nil, nil)
+	require.NoError(t, err)
+}
+
+func Test_NewTransport_uses_proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a forward proxy receives the absolute URL of the target
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte(`{"data": {"id": "1"}}`))
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	c := NewClient("api", "blars", "http://api.lightstep.invalid", WithTransport(transport), WithRetryMax(0), WithRateLimit(rate.Inf))
+	err = c.CallAPI(context.Background(), "GET", "projects/p/streams/1", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://api.lightstep.invalid/public/v0.2/blars/projects/p/streams/1"}, proxied)
+}
+
+func Test_NewTransport_rejects_invalid_config(t *testing.T) {
+	_, err := NewTransport(TransportConfig{ProxyURL: "not a url"})
+	assert.Error(t, err)
+
+	_, err = NewTransport(TransportConfig{CACertPEM: []byte("garbage")})
+	assert.Error(t, err)
+
+	_, err = NewTransport(TransportConfig{ClientCertPEM: []byte("cert")})
+	assert.ErrorContains(t, err, "both a client certificate and a client key")
+
+	_, err = NewTransport(TransportConfig{ClientCertPEM: []byte("cert"), ClientKeyPEM: []byte("key")})
+	assert.ErrorContains(t, err, "invalid client certificate or key")
+}
@@ -5,8 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
+	"sync"
 )
 
+// maxRoleBindingAttempts bounds how many times ModifyRoleBinding starts over when the role
+// binding is changed concurrently.
+const maxRoleBindingAttempts = 5
+
 type RoleBinding struct {
 	RoleName    string   `json:"role-name"`
 	ProjectName string   `json:"project-name"`
@@ -69,3 +75,86 @@ func (c *Client) UpdateRoleBinding(
 
 	return roleBinding.Attributes, nil
 }
+
+// ModifyRoleBinding updates the users of a role binding with a read-modify-write: modify is
+// given the current users and returns the users the binding should have. It must only add
+// or remove its own users, so it can be applied again to a binding changed by someone else.
+//
+// The API replaces the users of a binding as a whole and has no conditional update, so:
+//   - updates of the same binding made with this client, such as by the resources of one
+//     Terraform run, are serialized;
+//   - the binding is read back after writing it. When a concurrent writer overwrote it, the
+//     users this update wrote but are now missing, which includes the ones it read and didn't
+//     mean to remove, are added back and modify is applied again.
+//
+// A user bound by another writer between the read and the write can't be seen, so it is
+// removed by the write and not restored.
+func (c *Client) ModifyRoleBinding(
+	ctx context.Context,
+	projectName string,
+	roleName string,
+	modify func(users []string) []string,
+) (RoleBinding, error) {
+	id := RoleBinding{ProjectName: projectName, RoleName: roleName}.ID()
+	lock, _ := c.roleBindingLocks.LoadOrStore(id, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	current, err := c.ListRoleBinding(ctx, projectName, roleName)
+	if err != nil {
+		return RoleBinding{}, err
+	}
+
+	var lost []string
+	for attempt := 1; ; attempt++ {
+		users := modify(append(append([]string{}, current.Users...), lost...))
+		if sameUsers(users, current.Users) {
+			return current, nil
+		}
+		if attempt > maxRoleBindingAttempts {
+			return RoleBinding{}, fmt.Errorf("role binding %s was changed concurrently %d times in a row, giving up", id, maxRoleBindingAttempts)
+		}
+
+		if _, err := c.UpdateRoleBinding(ctx, projectName, roleName, users...); err != nil {
+			return RoleBinding{}, err
+		}
+
+		// another writer may have replaced the users between the read and the write; the
+		// users written but missing from the binding now were dropped by it
+		current, err = c.ListRoleBinding(ctx, projectName, roleName)
+		if err != nil {
+			return RoleBinding{}, err
+		}
+		lost = missingUsers(users, current.Users)
+	}
+}
+
+// missingUsers returns the users of want that aren't in have.
+func missingUsers(want []string, have []string) []string {
+	present := make(map[string]bool, len(have))
+	for _, u := range have {
+		present[u] = true
+	}
+	var missing []string
+	for _, u := range want {
+		if !present[u] {
+			missing = append(missing, u)
+		}
+	}
+	return missing
+}
+
+func sameUsers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
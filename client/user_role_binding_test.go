@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func Test_UserRoleBinding(t *testing.T) {
@@ -45,3 +47,170 @@ func Test_UserRoleBinding(t *testing.T) {
 		Users:       []string{"user1@lightstep.com"},
 	}, rb)
 }
+
+func Test_ModifyRoleBinding(t *testing.T) {
+	var mu sync.Mutex
+	users := []string{"existing@lightstep.com"}
+	var posts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPost {
+			var req struct {
+				Data RoleBinding `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			users = req.Data.Users
+
+			// the first write is overwritten by another writer that read the binding before it
+			posts++
+			if posts == 1 {
+				users = []string{"existing@lightstep.com", "other@lightstep.com"}
+			}
+		}
+
+		resp, err := json.Marshal(map[string]any{
+			"data": map[string]any{
+				"attributes": RoleBinding{RoleName: "Project Editor", ProjectName: "p", Users: users},
+			},
+		})
+		require.NoError(t, err)
+		w.Write(resp)
+	}))
+	defer server.Close()
+
+	c := NewClient("api", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+
+	// concurrent updates made with the same client are serialized
+	var wg sync.WaitGroup
+	for _, user := range []string{"a@lightstep.com", "b@lightstep.com", "c@lightstep.com"} {
+		user := user
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.ModifyRoleBinding(context.Background(), "p", "Project Editor", func(users []string) []string {
+				for _, u := range users {
+					if u == user {
+						return users
+					}
+				}
+				return append(users, user)
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	assert.ElementsMatch(t, []string{
+		"existing@lightstep.com",
+		"other@lightstep.com",
+		"a@lightstep.com",
+		"b@lightstep.com",
+		"c@lightstep.com",
+	}, users)
+	// nothing is written when the binding already has the change
+	posts = 0
+	mu.Unlock()
+
+	rb, err := c.ModifyRoleBinding(context.Background(), "p", "Project Editor", func(users []string) []string {
+		return users
+	})
+	require.NoError(t, err)
+	mu.Lock()
+	assert.Equal(t, 0, posts)
+	mu.Unlock()
+	assert.Len(t, rb.Users, 5)
+}
+
+func Test_ModifyRoleBinding_separateWriters(t *testing.T) {
+	var mu sync.Mutex
+	var users []string
+	// other holds its write back until ours is done, like a writer with a stale read
+	otherRead, wrote, otherWrote := make(chan struct{}), make(chan struct{}), make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		other := r.Header.Get("Authorization") == "bearer other"
+		if r.Method == http.MethodPost && other {
+			select {
+			case <-otherRead:
+			default:
+				close(otherRead)
+				<-wrote
+				defer close(otherWrote)
+			}
+		}
+		if r.Method == http.MethodGet && !other {
+			select {
+			case <-wrote:
+				<-otherWrote
+			default:
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPost {
+			var req struct {
+				Data RoleBinding `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			users = req.Data.Users
+			if !other {
+				select {
+				case <-wrote:
+				default:
+					close(wrote)
+				}
+			}
+		}
+
+		resp, err := json.Marshal(map[string]any{
+			"data": map[string]any{
+				"attributes": RoleBinding{RoleName: "Project Editor", ProjectName: "p", Users: users},
+			},
+		})
+		require.NoError(t, err)
+		w.Write(resp)
+	}))
+	defer server.Close()
+
+	addUser := func(user string) func([]string) []string {
+		return func(users []string) []string {
+			for _, u := range users {
+				if u == user {
+					return users
+				}
+			}
+			return append(users, user)
+		}
+	}
+
+	// another client reads the empty binding and holds its write back
+	otherClient := NewClient("other", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := otherClient.ModifyRoleBinding(context.Background(), "p", "Project Editor", addUser("other@lightstep.com"))
+		assert.NoError(t, err)
+	}()
+	<-otherRead
+
+	// meanwhile a user is bound elsewhere, and this client adds one; the other client's stale
+	// write then drops both
+	mu.Lock()
+	users = []string{"existing@lightstep.com"}
+	mu.Unlock()
+	c := NewClient("api", "blars", server.URL, WithRetryMax(0), WithRateLimit(rate.Inf))
+	rb, err := c.ModifyRoleBinding(context.Background(), "p", "Project Editor", addUser("a@lightstep.com"))
+	require.NoError(t, err)
+	wg.Wait()
+
+	assert.ElementsMatch(t, []string{"existing@lightstep.com", "a@lightstep.com", "other@lightstep.com"}, rb.Users)
+	mu.Lock()
+	assert.ElementsMatch(t, []string{"existing@lightstep.com", "a@lightstep.com", "other@lightstep.com"}, users)
+	mu.Unlock()
+}
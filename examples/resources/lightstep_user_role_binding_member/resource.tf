@@ -0,0 +1,7 @@
+# The following example grants "Project Editor" in "Project A" to one user, without
+# changing the role of the other users of "Project A", who can be managed elsewhere.
+resource "lightstep_user_role_binding_member" "proj_a_editor" {
+  project = "Project A"
+  role    = "Project Editor"
+  user    = "proj_a-editor@lightstep.com"
+}
@@ -160,20 +160,30 @@ func exportToHCL(wr io.Writer, d *client.UnifiedDashboard) error {
 }
 
 func Run(args ...string) error {
-	if len(os.Getenv("LIGHTSTEP_API_KEY")) == 0 {
-		log.Fatalf("error: LIGHTSTEP_API_KEY env variable must be set")
+	creds, err := client.ResolveCredentials(context.Background(), client.CredentialsConfig{
+		Org:           os.Getenv("LIGHTSTEP_ORG"),
+		APIURL:        firstNonEmpty(os.Getenv("LIGHTSTEP_API_URL"), os.Getenv("LIGHTSTEP_API_BASE_URL")),
+		APIKeyCommand: os.Getenv("LIGHTSTEP_API_KEY_COMMAND"),
+		APIKeyEnvVar:  "LIGHTSTEP_API_KEY",
+		Profile:       os.Getenv("LIGHTSTEP_PROFILE"),
+		ConfigFile:    os.Getenv("LIGHTSTEP_CONFIG_FILE"),
+	})
+	if err != nil {
+		log.Fatalf("error: could not resolve credentials: %v", err)
+	}
+
+	if len(creds.APIKey) == 0 {
+		log.Fatalf("error: LIGHTSTEP_API_KEY env variable, LIGHTSTEP_API_KEY_COMMAND or a profile with an api key must be set")
 	}
 
-	if len(os.Getenv("LIGHTSTEP_ORG")) == 0 {
-		log.Fatalf("error: LIGHTSTEP_ORG env variable must be set")
+	if len(creds.Org) == 0 {
+		log.Fatalf("error: LIGHTSTEP_ORG env variable or a profile with an organization must be set")
 	}
 
 	// default to public API environment
 	lightstepUrl := "https://api.lightstep.com"
-	if len(os.Getenv("LIGHTSTEP_API_URL")) > 0 {
-		lightstepUrl = os.Getenv("LIGHTSTEP_API_URL")
-	} else if len(os.Getenv("LIGHTSTEP_API_BASE_URL")) > 0 {
-		lightstepUrl = os.Getenv("LIGHTSTEP_API_BASE_URL")
+	if len(creds.APIURL) > 0 {
+		lightstepUrl = creds.APIURL
 	}
 
 	if len(args) < 4 {
@@ -184,7 +194,7 @@ func Run(args ...string) error {
 		log.Fatalf("error: only dashboard resources are supported at this time")
 	}
 
-	c := client.NewClient(os.Getenv("LIGHTSTEP_API_KEY"), os.Getenv("LIGHTSTEP_ORG"), lightstepUrl)
+	c := client.NewClient(creds.APIKey, creds.Org, lightstepUrl)
 	d, err := c.GetUnifiedDashboard(context.Background(), args[3], args[4])
 	if err != nil {
 		log.Fatalf("error: could not get dashboard: %v", err)
@@ -197,3 +207,12 @@ func Run(args ...string) error {
 
 	return nil
 }
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,477 @@
+package lightstep
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+// pointerMapping describes how one level of an API request document maps onto the
+// resource schema, so that the JSON pointers reported by the API in validation errors
+// can be turned into Terraform attribute paths.
+type pointerMapping struct {
+	// attribute is the name of the matching schema attribute. When empty, the API object
+	// is flattened into its parent in the schema.
+	attribute string
+	// indexed is set for list and set blocks: the next pointer segment is an element index.
+	indexed bool
+	// single is set for blocks with MaxItems: 1, which are plain objects in the API.
+	single bool
+	// nameAttribute is used to identify an element of an indexed block in messages.
+	nameAttribute string
+	children      map[string]*pointerMapping
+}
+
+// resolvedPointer is where a JSON pointer points in the resource schema.
+type resolvedPointer struct {
+	path cty.Path
+	// location is a human readable description of the blocks the pointer traverses
+	location []string
+	// inSet is set when the pointer goes into an element of a set block. Set elements
+	// have no index in Terraform, so path stops at the set attribute.
+	inSet bool
+}
+
+// pointerResolver turns the segments of a JSON pointer into a resolvedPointer.
+type pointerResolver func(segments []string) resolvedPointer
+
+// apiErrorDiagnostics converts an error returned by the API into diagnostics. Each
+// JSON:API error gets its own diagnostic and, when its source pointer can be matched
+// to the resource schema, is reported against the offending attribute.
+func apiErrorDiagnostics(err error, summary string, resolve pointerResolver) diag.Diagnostics {
+	var apiErr client.APIClientError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return diag.FromErr(fmt.Errorf("%s: %v", summary, err))
+	}
+
+	var diags diag.Diagnostics
+	for _, e := range apiErr.Errors {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: %s", summary, e.Message()),
+		}
+
+		pointer := e.Pointer()
+		if segments := splitPointer(pointer); len(segments) > 0 {
+			resolved := resolve(segments)
+			if len(resolved.path) > 0 {
+				d.AttributePath = resolved.path
+			}
+			if len(resolved.location) > 0 {
+				location := strings.Join(resolved.location, " > ")
+				if resolved.inSet {
+					// the attribute path doesn't tell which element was rejected
+					d.Summary = fmt.Sprintf("%s (%s)", d.Summary, location)
+				}
+				d.Detail = fmt.Sprintf("The Lightstep API rejected %s (%s).", location, pointer)
+			} else {
+				d.Detail = fmt.Sprintf("The Lightstep API rejected %s.", pointer)
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// splitPointer splits a JSON pointer into its unescaped segments, dropping the
+// JSON:API envelope ("/data/attributes") if present.
+func splitPointer(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	segments := strings.Split(pointer, "/")
+	for i := range segments {
+		segments[i] = unescape.Replace(segments[i])
+	}
+
+	if len(segments) > 0 && segments[0] == "data" {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 && segments[0] == "attributes" {
+		segments = segments[1:]
+	}
+	return segments
+}
+
+// resolvePointer walks the pointer segments through the mappings, reading element names
+// from the resource data along the way. Elements of the API request are in the order of
+// the resource data, which for sets is their hash order rather than the configuration's.
+func resolvePointer(d *schema.ResourceData, root map[string]*pointerMapping, segments []string) resolvedPointer {
+	var (
+		r    resolvedPointer
+		elem map[string]interface{}
+	)
+	// extend adds a step to the path, unless it already stopped at a set
+	extend := func(step func(cty.Path) cty.Path) {
+		if !r.inSet {
+			r.path = step(r.path)
+		}
+	}
+
+	children := root
+	for i := 0; i < len(segments) && children != nil; i++ {
+		m, ok := children[segments[i]]
+		if !ok {
+			break
+		}
+		children = m.children
+		if m.attribute == "" {
+			continue
+		}
+
+		var value interface{}
+		if len(r.path) == 0 {
+			value = d.Get(m.attribute)
+		} else {
+			value = elem[m.attribute]
+		}
+		extend(func(p cty.Path) cty.Path { return p.GetAttr(m.attribute) })
+
+		switch {
+		case m.single:
+			extend(func(p cty.Path) cty.Path { return p.IndexInt(0) })
+			elem = elementAt(value, 0)
+		case m.indexed:
+			if i+1 >= len(segments) {
+				return r
+			}
+			idx, err := strconv.Atoi(segments[i+1])
+			if err != nil {
+				return r
+			}
+			i++
+			if _, ok := value.(*schema.Set); ok {
+				r.inSet = true
+			}
+			extend(func(p cty.Path) cty.Path { return p.IndexInt(idx) })
+			elem = elementAt(value, idx)
+			r.location = append(r.location, describeElement(m, elem, idx))
+		default:
+			elem = nil
+		}
+	}
+	return r
+}
+
+func elementAt(value interface{}, idx int) map[string]interface{} {
+	var list []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		list = v
+	case *schema.Set:
+		list = v.List()
+	}
+	if idx < 0 || idx >= len(list) {
+		return nil
+	}
+	elem, _ := list[idx].(map[string]interface{})
+	return elem
+}
+
+func describeElement(m *pointerMapping, elem map[string]interface{}, idx int) string {
+	if m.nameAttribute != "" {
+		if name, ok := elem[m.nameAttribute].(string); ok && name != "" {
+			return fmt.Sprintf("%s %q", m.attribute, name)
+		}
+	}
+	return fmt.Sprintf("%s #%d", m.attribute, idx)
+}
+
+func leafMappings(attributes map[string]string) map[string]*pointerMapping {
+	mappings := make(map[string]*pointerMapping, len(attributes))
+	for apiName, attribute := range attributes {
+		mappings[apiName] = &pointerMapping{attribute: attribute}
+	}
+	return mappings
+}
+
+// getQueryPointerMappings maps client.MetricQueryWithAttributes onto the query schemas.
+func getQueryPointerMappings(legacy bool) map[string]*pointerMapping {
+	queryStringAttribute := "query_string"
+	if legacy {
+		queryStringAttribute = "tql"
+	}
+
+	mappings := leafMappings(map[string]string{
+		"query-name":   "query_name",
+		"query-string": queryStringAttribute,
+		"hidden":       "hidden",
+		"display-type": "display",
+	})
+	if !legacy {
+		mappings["hidden-queries"] = &pointerMapping{attribute: "hidden_queries"}
+		mappings["display-type-options"] = &pointerMapping{attribute: "display_type_options", single: true}
+		mappings["dependency-map-options"] = &pointerMapping{
+			attribute: "dependency_map_options",
+			single:    true,
+			children: leafMappings(map[string]string{
+				"scope":    "scope",
+				"map-type": "map_type",
+			}),
+		}
+		return mappings
+	}
+
+	finalWindowOperation := &pointerMapping{
+		attribute: "final_window_operation",
+		single:    true,
+		children: leafMappings(map[string]string{
+			"operator":        "operator",
+			"input-window-ms": "input_window_ms",
+		}),
+	}
+	metricQuery := leafMappings(map[string]string{
+		"metric":                              "metric",
+		"timeseries-operator":                 "timeseries_operator",
+		"timeseries-operator-input-window-ms": "timeseries_operator_input_window_ms",
+	})
+	metricQuery["group-by"] = &pointerMapping{
+		attribute: "group_by",
+		single:    true,
+		children: leafMappings(map[string]string{
+			"aggregation-method": "aggregation_method",
+			"label-keys":         "keys",
+		}),
+	}
+	metricQuery["final-window-operation"] = finalWindowOperation
+	mappings["metric-query"] = &pointerMapping{children: metricQuery}
+	mappings["composite-query"] = &pointerMapping{children: map[string]*pointerMapping{
+		"final-window-operation": finalWindowOperation,
+	}}
+
+	spansQuery := leafMappings(map[string]string{
+		"query":                    "query",
+		"operator":                 "operator",
+		"operator-input-window-ms": "operator_input_window_ms",
+		"latency-percentiles":      "latency_percentiles",
+		"group-by":                 "group_by_keys",
+	})
+	mappings["spans-query"] = &pointerMapping{attribute: "spans", single: true, children: spansQuery}
+	return mappings
+}
+
+// getChartPointerMappings maps client.UnifiedChart onto the chart and text panel schemas.
+func getChartPointerMappings(chartSchemaType ChartSchemaType) map[string]*pointerMapping {
+	mappings := leafMappings(map[string]string{
+		"title":       "name",
+		"description": "description",
+		"chart-type":  "type",
+		"rank":        "rank",
+		"subtitle":    "subtitle",
+		"text":        "text",
+	})
+	mappings["position"] = &pointerMapping{children: leafMappings(map[string]string{
+		"x-pos":  "x_pos",
+		"y-pos":  "y_pos",
+		"width":  "width",
+		"height": "height",
+	})}
+	mappings["thresholds"] = &pointerMapping{
+		attribute: "threshold",
+		indexed:   true,
+		children: leafMappings(map[string]string{
+			"operator": "operator",
+			"value":    "value",
+			"color":    "color",
+			"label":    "label",
+		}),
+	}
+	mappings["workflow_links"] = getWorkflowLinkPointerMapping()
+	mappings["metric-queries"] = &pointerMapping{
+		attribute:     "query",
+		indexed:       true,
+		nameAttribute: "query_name",
+		children:      getQueryPointerMappings(chartSchemaType == MetricChartSchema),
+	}
+	return mappings
+}
+
+func getWorkflowLinkPointerMapping() *pointerMapping {
+	return &pointerMapping{
+		attribute:     "workflow_link",
+		indexed:       true,
+		nameAttribute: "name",
+		children: leafMappings(map[string]string{
+			"name": "name",
+			"url":  "url",
+		}),
+	}
+}
+
+// textPanelsSegment is not part of the API: text panels are sent as charts, so pointers
+// into them are rewritten to use this segment before being resolved.
+const textPanelsSegment = "text-panels"
+
+// getDashboardPointerMappings maps client.UnifiedDashboardAttributes onto the dashboard schema.
+func getDashboardPointerMappings(chartSchemaType ChartSchemaType) map[string]*pointerMapping {
+	chart := &pointerMapping{
+		attribute:     "chart",
+		indexed:       true,
+		nameAttribute: "name",
+		children:      getChartPointerMappings(chartSchemaType),
+	}
+
+	group := leafMappings(map[string]string{
+		"title":           "title",
+		"rank":            "rank",
+		"visibility_type": "visibility_type",
+	})
+	group["charts"] = chart
+	group[textPanelsSegment] = &pointerMapping{
+		attribute:     "text_panel",
+		indexed:       true,
+		nameAttribute: "name",
+		children:      getChartPointerMappings(chartSchemaType),
+	}
+
+	mappings := leafMappings(map[string]string{
+		"name":            "dashboard_name",
+		"description":     "dashboard_description",
+		"event_query_ids": "event_query_ids",
+	})
+	mappings["charts"] = chart
+	mappings["groups"] = &pointerMapping{
+		attribute:     "group",
+		indexed:       true,
+		nameAttribute: "title",
+		children:      group,
+	}
+	mappings["labels"] = &pointerMapping{attribute: "label", indexed: true, nameAttribute: "key"}
+	mappings["template_variables"] = &pointerMapping{
+		attribute:     "template_variable",
+		indexed:       true,
+		nameAttribute: "name",
+		children: leafMappings(map[string]string{
+			"name":                     "name",
+			"default_values":           "default_values",
+			"suggestion_attribute_key": "suggestion_attribute_key",
+		}),
+	}
+	mappings["workflow_links"] = getWorkflowLinkPointerMapping()
+	return mappings
+}
+
+// dashboardPointerResolver resolves pointers into the dashboard request built by
+// getUnifiedDashboardAttributesFromResource, undoing the reshaping it does: top level
+// charts are sent as an implicit first group and text panels follow the charts of a group.
+func dashboardPointerResolver(d *schema.ResourceData, chartSchemaType ChartSchemaType, hasLegacyChartsIn bool) pointerResolver {
+	mappings := getDashboardPointerMappings(chartSchemaType)
+
+	return func(segments []string) resolvedPointer {
+		if len(segments) >= 2 && segments[0] == "groups" {
+			groupIdx, err := strconv.Atoi(segments[1])
+			if err == nil {
+				if hasLegacyChartsIn {
+					if groupIdx == 0 {
+						return resolvePointer(d, mappings, segments[2:])
+					}
+					groupIdx--
+				}
+
+				segments = append([]string{"groups", strconv.Itoa(groupIdx)}, segments[2:]...)
+				if len(segments) >= 4 && segments[2] == "charts" {
+					chartIdx, err := strconv.Atoi(segments[3])
+					group := elementAt(d.Get("group"), groupIdx)
+					if charts, ok := group["chart"].(*schema.Set); ok && err == nil && chartIdx >= charts.Len() {
+						segments[2] = textPanelsSegment
+						segments[3] = strconv.Itoa(chartIdx - charts.Len())
+					}
+				}
+			}
+		}
+		return resolvePointer(d, mappings, segments)
+	}
+}
+
+// getConditionPointerMappings maps client.UnifiedConditionAttributes onto the alert schemas.
+func getConditionPointerMappings(conditionSchemaType ConditionSchemaType) map[string]*pointerMapping {
+	legacy := conditionSchemaType == MetricConditionSchema
+
+	expression := func() *pointerMapping {
+		children := leafMappings(map[string]string{
+			"operand":              "operand",
+			"is-multi-alert":       "is_multi",
+			"enable-no-data-alert": "is_no_data",
+			"no-data-duration-ms":  "no_data_duration_ms",
+		})
+		children["thresholds"] = &pointerMapping{
+			attribute: "thresholds",
+			single:    true,
+			children: leafMappings(map[string]string{
+				"critical":             "critical",
+				"critical-duration-ms": "critical_duration_ms",
+				"warning":              "warning",
+				"warning-duration-ms":  "warning_duration_ms",
+			}),
+		}
+		return &pointerMapping{attribute: "expression", single: true, children: children}
+	}
+
+	queryAttribute := "query"
+	if legacy {
+		queryAttribute = "metric_query"
+	}
+
+	mappings := leafMappings(map[string]string{
+		"name":        "name",
+		"description": "description",
+		"custom-data": "custom_data",
+	})
+	mappings["labels"] = &pointerMapping{attribute: "label", indexed: true, nameAttribute: "key"}
+	mappings["expression"] = expression()
+	mappings["metric-queries"] = &pointerMapping{
+		attribute:     queryAttribute,
+		indexed:       true,
+		nameAttribute: "query_name",
+		children:      getQueryPointerMappings(legacy),
+	}
+	mappings["alerting-rules"] = &pointerMapping{
+		attribute:     "alerting_rule",
+		indexed:       true,
+		nameAttribute: "id",
+		children: leafMappings(map[string]string{
+			"message-destination-client-id": "id",
+			"update-interval-ms":            "update_interval",
+		}),
+	}
+	mappings["composite-alert"] = &pointerMapping{
+		attribute: "composite_alert",
+		single:    true,
+		children: map[string]*pointerMapping{
+			"alerts": {
+				attribute:     "alert",
+				indexed:       true,
+				nameAttribute: "name",
+				children: map[string]*pointerMapping{
+					"name":       {attribute: "name"},
+					"title":      {attribute: "title"},
+					"expression": expression(),
+					"queries": {
+						attribute:     "query",
+						indexed:       true,
+						nameAttribute: "query_name",
+						children:      getQueryPointerMappings(false),
+					},
+				},
+			},
+		},
+	}
+	return mappings
+}
+
+func conditionPointerResolver(d *schema.ResourceData, conditionSchemaType ConditionSchemaType) pointerResolver {
+	mappings := getConditionPointerMappings(conditionSchemaType)
+	return func(segments []string) resolvedPointer {
+		return resolvePointer(d, mappings, segments)
+	}
+}
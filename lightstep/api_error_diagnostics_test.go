@@ -0,0 +1,202 @@
+package lightstep
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+func validationError(errs ...client.APIError) error {
+	return client.APIClientError{
+		Response: &http.Response{StatusCode: http.StatusBadRequest},
+		Message:  "status 400",
+		Errors:   errs,
+	}
+}
+
+func pointerError(detail string, pointer string) client.APIError {
+	return client.APIError{Detail: detail, Source: &client.APIErrorSource{Pointer: pointer}}
+}
+
+func TestAPIErrorDiagnostics_alert(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceUnifiedCondition(UnifiedConditionSchema).Schema, map[string]interface{}{
+		"project_name": "p",
+		"name":         "alert",
+		"query": []interface{}{
+			map[string]interface{}{
+				"query_name":   "a",
+				"query_string": "metric foo",
+				"hidden":       false,
+			},
+			map[string]interface{}{
+				"query_name":   "b",
+				"query_string": "metric bar",
+				"hidden":       false,
+			},
+		},
+	})
+
+	diags := apiErrorDiagnostics(
+		validationError(
+			pointerError("unknown metric bar", "/data/attributes/metric-queries/1/query-string"),
+			pointerError("must be positive", "/expression/thresholds/critical"),
+			client.APIError{Title: "something else went wrong"},
+		),
+		"failed to create metric condition",
+		conditionPointerResolver(d, UnifiedConditionSchema),
+	)
+
+	require.Len(t, diags, 3)
+	assert.Equal(t, diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "failed to create metric condition: unknown metric bar",
+		Detail:        `The Lightstep API rejected query "b" (/data/attributes/metric-queries/1/query-string).`,
+		AttributePath: cty.GetAttrPath("query").IndexInt(1).GetAttr("query_string"),
+	}, diags[0])
+	assert.Equal(t,
+		cty.GetAttrPath("expression").IndexInt(0).GetAttr("thresholds").IndexInt(0).GetAttr("critical"),
+		diags[1].AttributePath,
+	)
+	assert.Equal(t, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "failed to create metric condition: something else went wrong",
+	}, diags[2])
+}
+
+func TestAPIErrorDiagnostics_dashboard(t *testing.T) {
+	chart := func(name string, rank int) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"rank": rank,
+			"type": "timeseries",
+			"query": []interface{}{
+				map[string]interface{}{
+					"query_name":   "a",
+					"query_string": "metric foo",
+					"hidden":       false,
+				},
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceUnifiedDashboard(UnifiedChartSchema).Schema, map[string]interface{}{
+		"project_name":   "p",
+		"dashboard_name": "dashboard",
+		"group": []interface{}{
+			map[string]interface{}{
+				"rank":            0,
+				"title":           "Overview",
+				"visibility_type": "explicit",
+				"chart":           []interface{}{chart("CPU", 0)},
+				"text_panel": []interface{}{
+					map[string]interface{}{"name": "Notes", "text": "hello"},
+				},
+			},
+		},
+	})
+
+	diags := apiErrorDiagnostics(
+		validationError(
+			pointerError("bad query", "/groups/0/charts/0/metric-queries/0/query-string"),
+			pointerError("too long", "/groups/0/charts/1/text"),
+		),
+		"failed to create dashboard",
+		dashboardPointerResolver(d, UnifiedChartSchema, false),
+	)
+
+	require.Len(t, diags, 2)
+	// groups are a set, whose elements can't be pointed at
+	assert.Equal(t, cty.GetAttrPath("group"), diags[0].AttributePath)
+	assert.Equal(t,
+		`failed to create dashboard: bad query (group "Overview" > chart "CPU" > query "a")`,
+		diags[0].Summary,
+	)
+	assert.Equal(t,
+		`The Lightstep API rejected group "Overview" > chart "CPU" > query "a" (/groups/0/charts/0/metric-queries/0/query-string).`,
+		diags[0].Detail,
+	)
+	assert.Equal(t, cty.GetAttrPath("group"), diags[1].AttributePath)
+	assert.Equal(t, `failed to create dashboard: too long (group "Overview" > text_panel "Notes")`, diags[1].Summary)
+
+	legacy := schema.TestResourceDataRaw(t, resourceUnifiedDashboard(UnifiedChartSchema).Schema, map[string]interface{}{
+		"project_name":   "p",
+		"dashboard_name": "dashboard",
+		"chart":          []interface{}{chart("Latency", 0)},
+	})
+	diags = apiErrorDiagnostics(
+		validationError(pointerError("bad query", "/groups/0/charts/0/metric-queries/0/query-string")),
+		"failed to update dashboard",
+		dashboardPointerResolver(legacy, UnifiedChartSchema, true),
+	)
+	require.Len(t, diags, 1)
+	assert.Equal(t, cty.GetAttrPath("chart"), diags[0].AttributePath)
+	assert.Equal(t, `failed to update dashboard: bad query (chart "Latency" > query "a")`, diags[0].Summary)
+}
+
+func TestAPIErrorDiagnostics_dashboardSetOrder(t *testing.T) {
+	chart := func(name string, rank int) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"rank": rank,
+			"type": "timeseries",
+			"query": []interface{}{
+				map[string]interface{}{
+					"query_name":   "a",
+					"query_string": "metric " + name,
+					"hidden":       false,
+				},
+			},
+		}
+	}
+	group := func(title string, rank int, charts ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"rank":            rank,
+			"title":           title,
+			"visibility_type": "explicit",
+			"chart":           charts,
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceUnifiedDashboard(UnifiedChartSchema).Schema, map[string]interface{}{
+		"project_name":   "p",
+		"dashboard_name": "dashboard",
+		"group": []interface{}{
+			group("Frontend", 0, chart("Requests", 0), chart("Errors", 1)),
+			group("Backend", 1, chart("CPU", 0), chart("Memory", 1)),
+		},
+	})
+
+	// the request lists groups and charts in the order of the sets, not of the configuration
+	groups, _, err := buildGroups(d.Get("group").(*schema.Set).List(), nil)
+	require.NoError(t, err)
+
+	for groupIdx, g := range groups {
+		for chartIdx, c := range g.Charts {
+			pointer := fmt.Sprintf("/groups/%d/charts/%d/title", groupIdx, chartIdx)
+			diags := apiErrorDiagnostics(
+				validationError(pointerError("invalid title", pointer)),
+				"failed to create dashboard",
+				dashboardPointerResolver(d, UnifiedChartSchema, false),
+			)
+
+			require.Len(t, diags, 1)
+			assert.Equal(t, cty.GetAttrPath("group"), diags[0].AttributePath)
+			location := fmt.Sprintf("group %q > chart %q", g.Title, c.Title)
+			assert.Equal(t, fmt.Sprintf("failed to create dashboard: invalid title (%s)", location), diags[0].Summary)
+			assert.Equal(t, fmt.Sprintf("The Lightstep API rejected %s (%s).", location, pointer), diags[0].Detail)
+		}
+	}
+}
+
+func TestAPIErrorDiagnostics_unstructured(t *testing.T) {
+	diags := apiErrorDiagnostics(fmt.Errorf("connection reset"), "failed to create dashboard", nil)
+	assert.Equal(t, diag.FromErr(fmt.Errorf("failed to create dashboard: connection reset")), diags)
+}
@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,19 +37,14 @@ func dataSourceStream() *schema.Resource {
 }
 
 func dataSourceLightstepStreamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	s, err := c.GetStream(ctx, d.Get("project_name").(string), d.Get("stream_id").(string))
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get stream: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
-			return diag.FromErr(fmt.Errorf("stream not found: %v", apiErr))
+			return diag.FromErr(fmt.Errorf("stream not found: %v", err))
 		}
-		return diag.FromErr(fmt.Errorf("failed to get stream: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get stream: %v", err))
 	}
 	d.SetId(s.ID)
 	if err := d.Set("stream_name", s.Attributes.Name); err != nil {
@@ -25,14 +25,15 @@ data "lightstep_stream" "stream_ds" {
 }
 `
 	var stream client.Stream
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: streamConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.aggie_errors_ds", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.aggie_errors_ds", &stream),
 					resource.TestCheckResourceAttr("data.lightstep_stream.stream_ds", "stream_name", "Aggie Errors DS"),
 					resource.TestCheckResourceAttr("data.lightstep_stream.stream_ds", "stream_query", "service IN (\"aggie_ds\") AND \"error\" IN (\"true\")"),
 				),
@@ -0,0 +1,72 @@
+package lightstep
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceWebhookTemplatePreview() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"template": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validateWebhookTemplate,
+			Description:      "Webhook payload body text template, as set on a `lightstep_webhook_destination`",
+		},
+	}
+	for _, status := range webhookAlertStatuses {
+		s[status] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("The template rendered with a sample alert whose status is `%s`", status),
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to render a webhook template locally with sample alerts, to review its output in plans or assert it in tests.",
+		ReadContext: dataSourceWebhookTemplatePreviewRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceWebhookTemplatePreviewRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	text := d.Get("template").(string)
+
+	t, err := parseWebhookTemplate(text)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if len(t.unknownFuncs) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown template functions",
+			Detail:   fmt.Sprintf("The preview doesn't know the functions %s, so calls to them render as a placeholder such as \"<%s>\" rather than what Lightstep would send.", strings.Join(t.unknownFuncs, ", "), t.unknownFuncs[0]),
+		})
+	}
+	if len(t.unknownFields) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown template fields",
+			Detail:   fmt.Sprintf("The preview's sample alert has no fields %s, so they render as a placeholder such as \"<.%s>\" rather than what Lightstep would send.", strings.Join(t.unknownFields, ", "), t.unknownFields[0]),
+		})
+	}
+
+	for _, status := range webhookAlertStatuses {
+		rendered, err := renderWebhookTemplate(t, sampleWebhookAlerts[status])
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if err := d.Set(status, rendered); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(text))))
+	return diags
+}
@@ -0,0 +1,72 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTemplatePreview(t *testing.T) {
+	ds := dataSourceWebhookTemplatePreview()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"template": `{"text": "{{.AlertName}} is {{.Status}}{{if eq .Status "critical"}} ({{.Value}} > {{.Threshold}}){{end}}", "team": "{{index .Labels "team"}}"}`,
+	})
+	require.False(t, ds.ReadContext(context.Background(), d, nil).HasError())
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, `{"text": "Checkout latency is warning", "team": "checkout"}`, d.Get("warning"))
+	assert.Equal(t, `{"text": "Checkout latency is critical (720 > 500)", "team": "checkout"}`, d.Get("critical"))
+	assert.Equal(t, `{"text": "Checkout latency is resolved", "team": "checkout"}`, d.Get("resolved"))
+	assert.Equal(t, `{"text": "Checkout latency is no_data", "team": "checkout"}`, d.Get("no_data"))
+
+	// fields the sample alert doesn't have render as placeholders
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"template": `{{.Severity}} {{if .Severity}}{{$.Priority}}{{end}} {{with .Labels}}{{.team}}{{end}}`,
+	})
+	diags := ds.ReadContext(context.Background(), d, nil)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Unknown template fields", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "Priority, Severity")
+	assert.Equal(t, `<.Severity> <.Priority> checkout`, d.Get("critical"))
+
+	// functions provided by Lightstep have stand-ins, others render as placeholders
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"template": `{"text": {{toJSON .AlertName}}, "link": "{{shortLink .AlertURL | upper}}"}`,
+	})
+	diags = ds.ReadContext(context.Background(), d, nil)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "shortLink, upper")
+	assert.Equal(t, `{"text": "Checkout latency", "link": "<upper>"}`, d.Get("warning"))
+}
+
+func TestWebhookTemplate_validation(t *testing.T) {
+	r := resourceWebhookDestination()
+	config := func(template string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_name":     "offline",
+			"destination_name": "hook",
+			"url":              "https://example.com/hook",
+			"template":         template,
+		})
+	}
+
+	diags := r.Validate(config(`{"text": "{{.AlertName}"}`))
+	require.True(t, diags.HasError())
+	assert.Equal(t, "invalid webhook template", diags[0].Summary)
+
+	diags = r.Validate(config(`{{if .Status}}unterminated`))
+	require.True(t, diags.HasError())
+
+	// functions are provided by Lightstep when rendering, so they aren't checked
+	diags = r.Validate(config(`{"text": {{toJSON .AlertName}}}`))
+	assert.False(t, diags.HasError(), "%v", diags)
+}
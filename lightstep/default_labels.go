@@ -0,0 +1,163 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+// defaultLabelsSchema is the provider's default_labels block.
+func defaultLabelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Labels added to every dashboard and alert managed by the provider. A label set on a resource takes precedence over a default label with the same key.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"labels": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Map of label keys to values.",
+				},
+			},
+		},
+	}
+}
+
+// labelsAllSchema is the computed union of a resource's labels and the provider's default labels.
+func labelsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "All labels of the resource, including those inherited from the provider's `default_labels`.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// expandDefaultLabels reads the provider's default_labels block, sorted by key.
+func expandDefaultLabels(d *schema.ResourceData) []client.Label {
+	blocks := d.Get("default_labels").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+
+	var labels []client.Label
+	for k, v := range blocks[0].(map[string]interface{})["labels"].(map[string]interface{}) {
+		labels = append(labels, client.Label{Key: k, Value: v.(string)})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Key < labels[j].Key })
+	return labels
+}
+
+// mergeLabels returns the resource's labels followed by the default labels whose key the
+// resource doesn't set. Resource labels come first so API error pointers into the label
+// list keep matching the resource's label blocks.
+func mergeLabels(labels []client.Label, defaults []client.Label) []client.Label {
+	merged := append([]client.Label{}, labels...)
+	for _, def := range defaults {
+		overridden := false
+		for _, l := range labels {
+			if l.Key == def.Key && (l.Key != "" || l.Value == def.Value) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, def)
+		}
+	}
+	return merged
+}
+
+// setLabels stores labels read from the API in labels_all and, without the ones inherited
+// from the provider, in label. A label equal to a default is kept in label when the
+// resource already sets it itself, so restating a default doesn't cause a diff.
+func setLabels(d *schema.ResourceData, apiLabels []client.Label, defaults []client.Label) error {
+	own, err := buildLabels(d.Get("label").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	var labels []client.Label
+	for _, l := range apiLabels {
+		if containsLabel(defaults, l) && !containsLabel(own, l) {
+			continue
+		}
+		labels = append(labels, l)
+	}
+
+	if err := d.Set("label", extractLabels(labels)); err != nil {
+		return fmt.Errorf("unable to set labels resource field: %v", err)
+	}
+	if err := d.Set("labels_all", extractLabels(apiLabels)); err != nil {
+		return fmt.Errorf("unable to set labels_all resource field: %v", err)
+	}
+	return nil
+}
+
+// customizeDiffLabelsAll plans labels_all, so that changing the provider's default labels
+// updates every resource inheriting them.
+func customizeDiffLabelsAll(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("label") {
+		return d.SetNewComputed("labels_all")
+	}
+
+	labels, err := buildLabels(d.Get("label").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	var defaults []client.Label
+	if meta, ok := m.(*providerMeta); ok {
+		defaults = meta.defaultLabels
+	}
+	merged := mergeLabels(labels, defaults)
+
+	old, err := buildLabels(d.Get("labels_all").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	if sameLabelSet(old, merged) {
+		return nil
+	}
+	return d.SetNew("labels_all", extractLabels(merged))
+}
+
+func containsLabel(labels []client.Label, label client.Label) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func sameLabelSet(a []client.Label, b []client.Label) bool {
+	for _, l := range a {
+		if !containsLabel(b, l) {
+			return false
+		}
+	}
+	for _, l := range b {
+		if !containsLabel(a, l) {
+			return false
+		}
+	}
+	return true
+}
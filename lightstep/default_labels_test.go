@@ -0,0 +1,72 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+func Test_mergeLabels(t *testing.T) {
+	defaults := []client.Label{{Key: "cost_center", Value: "42"}, {Key: "team", Value: "core"}}
+
+	assert.Equal(t, defaults, mergeLabels(nil, defaults))
+	assert.Equal(t,
+		[]client.Label{{Key: "team", Value: "web"}, {Value: "critical"}, {Key: "cost_center", Value: "42"}},
+		mergeLabels([]client.Label{{Key: "team", Value: "web"}, {Value: "critical"}}, defaults),
+	)
+	assert.Equal(t,
+		[]client.Label{{Value: "critical"}},
+		mergeLabels([]client.Label{{Value: "critical"}}, []client.Label{{Value: "critical"}}),
+	)
+}
+
+func TestDefaultLabels_fakeAPI(t *testing.T) {
+	defaultLabels := []interface{}{map[string]interface{}{
+		"labels": map[string]interface{}{"team": "core", "cost_center": "42"},
+	}}
+	p, server := testFakeAPIProviderWithConfig(t, map[string]interface{}{"default_labels": defaultLabels})
+	r := p.ResourcesMap["lightstep_dashboard"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":   "offline",
+		"dashboard_name": "labeled",
+		"label": []interface{}{
+			map[string]interface{}{"key": "team", "value": "web"},
+			map[string]interface{}{"value": "critical"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	stored, ok := server.Get("offline", "metric_dashboards", d.Id())
+	require.True(t, ok)
+	assert.ElementsMatch(t, []interface{}{
+		map[string]interface{}{"label_key": "team", "label_value": "web"},
+		map[string]interface{}{"label_key": "", "label_value": "critical"},
+		map[string]interface{}{"label_key": "cost_center", "label_value": "42"},
+	}, stored["attributes"].(map[string]interface{})["labels"])
+
+	// the inherited label only shows up in labels_all
+	assert.Equal(t, 2, d.Get("label").(*schema.Set).Len())
+	assert.Equal(t, 3, d.Get("labels_all").(*schema.Set).Len())
+
+	// overriding a default doesn't cause a perpetual diff
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// changing the defaults plans an update of labels_all
+	p.Meta().(*providerMeta).defaultLabels = []client.Label{{Key: "cost_center", Value: "43"}}
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.Empty())
+	assert.False(t, diff.RequiresNew())
+}
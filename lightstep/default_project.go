@@ -0,0 +1,100 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const missingProjectError = "project_name must be set, either on the resource or as the provider's `project`"
+
+// withDefaultProject makes project_name optional in r, falling back to the provider's project:
+//
+//   - new resources without project_name are planned in the provider's project;
+//   - existing resources stay in the project recorded in their state when the provider's
+//     project changes, so they are never moved or replaced behind the user's back;
+//   - import IDs may omit the "<project>." prefix;
+//   - data sources read from the provider's project.
+func withDefaultProject(r *schema.Resource, isDataSource bool) {
+	s, ok := r.Schema["project_name"]
+	if !ok {
+		return
+	}
+
+	s.Required = false
+	s.Optional = true
+	s.Computed = true
+	if s.Description == "" {
+		s.Description = "Lightstep project name."
+	}
+	s.Description = strings.TrimSuffix(s.Description, ".") + ". Defaults to the provider's `project`."
+
+	if isDataSource {
+		read := r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if d.Get("project_name").(string) == "" {
+				project := defaultProject(m)
+				if project == "" {
+					return diag.Errorf(missingProjectError)
+				}
+				if err := d.Set("project_name", project); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			return read(ctx, d, m)
+		}
+		return
+	}
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = customizeDiffDefaultProject
+	} else {
+		r.CustomizeDiff = customdiff.Sequence(customizeDiffDefaultProject, r.CustomizeDiff)
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if project := defaultProject(m); project != "" && !strings.Contains(d.Id(), ".") {
+				d.SetId(fmt.Sprintf("%s.%s", project, d.Id()))
+			}
+			return importState(ctx, d, m)
+		}
+	}
+}
+
+// customizeDiffDefaultProject plans project_name for resources that don't set it.
+func customizeDiffDefaultProject(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// project_name is computed, so an existing resource that omits it keeps the project in
+	// its state, see withDefaultProject
+	if d.Id() != "" {
+		return nil
+	}
+
+	// an omitted project_name is planned as unknown, so tell it apart from one set to an
+	// unknown value by looking at the configuration itself when it's available
+	if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() && !config.GetAttr("project_name").IsNull() {
+		return nil
+	}
+	if d.NewValueKnown("project_name") && d.Get("project_name").(string) != "" {
+		return nil
+	}
+
+	project := defaultProject(m)
+	if project == "" {
+		return fmt.Errorf(missingProjectError)
+	}
+	return d.SetNew("project_name", project)
+}
+
+func defaultProject(m interface{}) string {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return ""
+	}
+	return meta.project
+}
@@ -0,0 +1,86 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client/fake"
+)
+
+func TestDefaultProject_fakeAPI(t *testing.T) {
+	p, server := testFakeAPIProviderWithConfig(t, map[string]interface{}{"project": "offline"})
+	r := p.ResourcesMap["lightstep_event_query"]
+	ctx := context.Background()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":         "deploys",
+		"type":         "test-type",
+		"source":       "test-source",
+		"query_string": "logs",
+	})
+	diff, err := r.Diff(ctx, nil, config, p.Meta())
+	require.NoError(t, err)
+	assert.Equal(t, "offline", diff.Attributes["project_name"].New)
+
+	state, diags := r.Apply(ctx, nil, diff, p.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	_, ok := server.Get("offline", "event_queries", state.ID)
+	assert.True(t, ok)
+
+	// changing the provider's project neither moves nor replaces existing resources
+	p.Meta().(*providerMeta).project = "elsewhere"
+	diff, err = r.Diff(ctx, state, config, p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// but setting project_name explicitly does
+	explicit := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_name": "elsewhere",
+		"name":         "deploys",
+		"type":         "test-type",
+		"source":       "test-source",
+		"query_string": "logs",
+	})
+	diff, err = r.Diff(ctx, state, explicit, p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	p.Meta().(*providerMeta).project = ""
+	_, err = r.Diff(ctx, nil, config, p.Meta())
+	assert.EqualError(t, err, missingProjectError)
+}
+
+func TestDefaultProject_import(t *testing.T) {
+	p, server := testFakeAPIProviderWithConfig(t, map[string]interface{}{"project": "offline"})
+	r := p.ResourcesMap["lightstep_event_query"]
+	id := server.Put("offline", "event_queries", fake.Object{
+		"attributes": fake.Object{"name": "deploys", "query_type": "test-type", "source": "test-source", "query_string": "logs"},
+	})
+
+	d := r.Data(nil)
+	d.SetId(id)
+	imported, err := r.Importer.StateContext(context.Background(), d, p.Meta())
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, id, imported[0].Id())
+	assert.Equal(t, "offline", imported[0].Get("project_name"))
+}
+
+func TestDefaultProject_dataSource(t *testing.T) {
+	p, server := testFakeAPIProviderWithConfig(t, map[string]interface{}{"project": "offline"})
+	ds := p.DataSourcesMap["lightstep_stream"]
+	id := server.Put("offline", "streams", fake.Object{
+		"attributes": fake.Object{"name": "checkout", "query": `service IN ("checkout")`},
+	})
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"stream_id": id})
+	diags := ds.ReadContext(context.Background(), d, p.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "offline", d.Get("project_name"))
+	assert.Equal(t, "checkout", d.Get("stream_name"))
+}
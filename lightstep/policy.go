@@ -0,0 +1,156 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+// policy holds the organization's rules for dashboards and alerts, checked at plan time.
+type policy struct {
+	requiredLabelKeys    []string
+	dashboardNamePattern *regexp.Regexp
+	requireAlertingRule  bool
+}
+
+// policySchema is the provider's policy block.
+func policySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Rules every dashboard and alert must follow. Violations fail the plan.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"required_label_keys": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Label keys every dashboard and alert must have, either set on the resource or inherited from `default_labels`.",
+				},
+				"dashboard_name_pattern": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Regular expression every dashboard name must match.",
+				},
+				"require_alerting_rule": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Require every alert to have at least one `alerting_rule`.",
+				},
+			},
+		},
+	}
+}
+
+// expandPolicy reads the provider's policy block. It returns nil when there is none.
+// Diagnostics point at the attribute that is invalid.
+func expandPolicy(d *schema.ResourceData) (*policy, diag.Diagnostics) {
+	blocks := d.Get("policy").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	p := &policy{
+		requiredLabelKeys:   buildStringSlice(block["required_label_keys"].(*schema.Set).List()),
+		requireAlertingRule: block["require_alerting_rule"].(bool),
+	}
+	sort.Strings(p.requiredLabelKeys)
+
+	if pattern := block["dashboard_name_pattern"].(string); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid policy",
+				Detail:        fmt.Sprintf("invalid dashboard_name_pattern: %v", err),
+				AttributePath: cty.GetAttrPath("policy").IndexInt(0).GetAttr("dashboard_name_pattern"),
+			}}
+		}
+		p.dashboardNamePattern = re
+	}
+	return p, nil
+}
+
+// customizeDiffDashboardPolicy enforces the provider's policy on a dashboard.
+func customizeDiffDashboardPolicy(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta, ok := m.(*providerMeta)
+	if !ok || meta.policy == nil {
+		return nil
+	}
+
+	var violations []string
+	if re := meta.policy.dashboardNamePattern; re != nil && d.NewValueKnown("dashboard_name") {
+		if name := d.Get("dashboard_name").(string); !re.MatchString(name) {
+			violations = append(violations, fmt.Sprintf("dashboard_name %q does not match %q", name, re.String()))
+		}
+	}
+	violations = append(violations, missingLabelKeys(d, meta)...)
+	return policyError(violations)
+}
+
+// customizeDiffConditionPolicy enforces the provider's policy on an alert.
+func customizeDiffConditionPolicy(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta, ok := m.(*providerMeta)
+	if !ok || meta.policy == nil {
+		return nil
+	}
+
+	var violations []string
+	if meta.policy.requireAlertingRule && d.NewValueKnown("alerting_rule") {
+		if d.Get("alerting_rule").(*schema.Set).Len() == 0 {
+			violations = append(violations, "at least one alerting_rule is required")
+		}
+	}
+	violations = append(violations, missingLabelKeys(d, meta)...)
+	return policyError(violations)
+}
+
+// missingLabelKeys checks the labels the resource will have once the default labels are merged in.
+func missingLabelKeys(d *schema.ResourceDiff, meta *providerMeta) []string {
+	if len(meta.policy.requiredLabelKeys) == 0 || !d.NewValueKnown("label") {
+		return nil
+	}
+
+	labels, err := buildLabels(d.Get("label").(*schema.Set).List())
+	if err != nil {
+		// reported by the resource itself
+		return nil
+	}
+
+	var missing []string
+	for _, key := range meta.policy.requiredLabelKeys {
+		if !hasLabelKey(mergeLabels(labels, meta.defaultLabels), key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("missing required label keys: %s", strings.Join(missing, ", "))}
+}
+
+func hasLabelKey(labels []client.Label, key string) bool {
+	for _, l := range labels {
+		if l.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func policyError(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("policy violation: %s", strings.Join(violations, "; "))
+}
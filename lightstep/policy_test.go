@@ -0,0 +1,77 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_fakeAPI(t *testing.T) {
+	p, _ := testFakeAPIProviderWithConfig(t, map[string]interface{}{
+		"default_labels": []interface{}{map[string]interface{}{
+			"labels": map[string]interface{}{"team": "core"},
+		}},
+		"policy": []interface{}{map[string]interface{}{
+			"required_label_keys":    []interface{}{"team", "service"},
+			"dashboard_name_pattern": "^[a-z]+ - .+$",
+			"require_alerting_rule":  true,
+		}},
+	})
+	ctx := context.Background()
+
+	plan := func(resourceType string, config map[string]interface{}) error {
+		_, err := p.ResourcesMap[resourceType].Diff(ctx, nil, terraform.NewResourceConfigRaw(config), p.Meta())
+		return err
+	}
+
+	// team is inherited from the default labels
+	assert.NoError(t, plan("lightstep_dashboard", map[string]interface{}{
+		"project_name":   "offline",
+		"dashboard_name": "web - overview",
+		"label":          []interface{}{map[string]interface{}{"key": "service", "value": "web"}},
+	}))
+
+	err := plan("lightstep_dashboard", map[string]interface{}{
+		"project_name":   "offline",
+		"dashboard_name": "Overview",
+		"label":          []interface{}{map[string]interface{}{"value": "service"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `dashboard_name "Overview" does not match "^[a-z]+ - .+$"`)
+	assert.Contains(t, err.Error(), "missing required label keys: service")
+
+	err = plan("lightstep_alert", map[string]interface{}{
+		"project_name": "offline",
+		"name":         "no rules",
+		"label":        []interface{}{map[string]interface{}{"key": "service", "value": "web"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one alerting_rule is required")
+
+	assert.NoError(t, plan("lightstep_alert", map[string]interface{}{
+		"project_name": "offline",
+		"name":         "with rules",
+		"label":        []interface{}{map[string]interface{}{"key": "service", "value": "web"}},
+		"alerting_rule": []interface{}{map[string]interface{}{
+			"id": "abc123",
+		}},
+	}))
+}
+
+func TestPolicy_invalidPattern(t *testing.T) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"organization": "org",
+		"api_key":      "key",
+		"policy": []interface{}{map[string]interface{}{
+			"dashboard_name_pattern": "[",
+		}},
+	}))
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Invalid policy", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("policy").IndexInt(0).GetAttr("dashboard_name_pattern"), diags[0].AttributePath)
+}
@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,14 +19,40 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/version"
 )
 
+// providerMeta is the meta value handed to resources and data sources: the API client
+// plus the provider-level settings resources apply on top of their own configuration.
+type providerMeta struct {
+	*client.Client
+
+	// defaultLabels are merged into the labels of every dashboard and alert
+	defaultLabels []client.Label
+	// project is used by resources and data sources that don't set project_name
+	project string
+	// policy is checked when planning dashboards and alerts, nil when there is none
+	policy *policy
+}
+
+// providerHooks customize the client configured by a provider built with newProvider.
+// Tests use them to record and replay API traffic.
+type providerHooks struct {
+	// wrapTransport wraps the transport used to reach the API
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+	// clientOptions are applied after the ones read from the provider configuration
+	clientOptions []client.Option
+}
+
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return newProvider(providerHooks{})
+}
+
+func newProvider(hooks providerHooks) *schema.Provider {
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"organization": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_ORG", nil),
-				Description: "The name of the Lightstep organization",
+				Description: "The name of the Lightstep organization. Required unless set by the selected `profile`.",
 			},
 			"environment": {
 				Type:         schema.TypeString,
@@ -37,7 +66,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"LIGHTSTEP_API_URL", "LIGHTSTEP_API_BASE_URL"}, ""),
-				Description: "The base URL for the Lightstep API. This setting takes precedent over 'environment'. For example, https://api.lightstep.com",
+				Description: "The base URL for the Lightstep API. This setting takes precedent over 'environment'. For example, https://api.lightstep.com. When neither is set, the `api_url` of the selected profile is used.",
 			},
 			"api_key": {
 				Type:        schema.TypeString,
@@ -53,60 +82,231 @@ func Provider() *schema.Provider {
 				Description: "Environment variable for Lightstep API key.",
 				Default:     "LIGHTSTEP_API_KEY",
 			},
+			"api_key_command": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("LIGHTSTEP_API_KEY_COMMAND", ""),
+				ConflictsWith: []string{"api_key"},
+				Description:   "Command run through the shell to print the API key on its standard output, e.g. a secrets manager CLI. Its output is cached by the provider process, which Terraform starts anew for every plan, apply and provider alias, so the command runs once for each of them.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_PROFILE", ""),
+				Description: "Profile of the configuration file to read the organization, API URL and API key from. The `default` profile is used when unset and present.",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_CONFIG_FILE", ""),
+				Description: "Path of the profiles configuration file. Defaults to `~/.lightstep/config`.",
+			},
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_PROJECT", ""),
+				Description: "Default project of resources and data sources that don't set `project_name`. Changing it doesn't move existing resources; set `project_name` on a resource to move it.",
+			},
+			"default_labels": defaultLabelsSchema(),
+			"policy":         policySchema(),
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_READ_ONLY", false),
+				Description: "Refuse every API request that could create, update or delete a resource, so plans can run with production credentials without any risk of writes. Read-only POST requests, such as validating snooze rules and translating queries, are still allowed.",
+			},
+			"validate_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_VALIDATE_CREDENTIALS", false),
+				Description: "Check that the API key is valid and the organization exists when the provider is configured, instead of failing at the first resource. Set `LIGHTSTEP_SKIP_CREDENTIALS_VALIDATION=true` to skip the check, e.g. for plan-only runs with a read-only key.",
+			},
+			"check_write_permission": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LIGHTSTEP_CHECK_WRITE_PERMISSION", false),
+				Description: "Along with `validate_credentials`, also check that the API key may change resources. The Lightstep API has no way to query permissions, so this sends a DELETE request for the stream `terraform-provider-permission-check`, which does not exist, to the project set by `project`, or to the first project of the organization when it is unset; it changes nothing but shows up in that project's audit logs. Ignored when `read_only` is set.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LIGHTSTEP_MAX_RETRIES", client.DefaultRetryMax),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request is retried after a rate-limited (429) or server error (5xx) response.",
+			},
+			"retry_wait_min": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("LIGHTSTEP_RETRY_WAIT_MIN", client.DefaultRetryWaitMin.String()),
+				ValidateDiagFunc: validateDuration,
+				Description:      "Minimum time to wait before retrying a request, as a duration such as `500ms` or `1s`. Retries back off exponentially with jitter from this value.",
+			},
+			"retry_wait_max": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("LIGHTSTEP_RETRY_WAIT_MAX", client.DefaultRetryWaitMax.String()),
+				ValidateDiagFunc: validateDuration,
+				Description:      "Maximum time to wait between retries, as a duration such as `30s`. A `Retry-After` header returned by the API takes precedence.",
+			},
+			"otlp_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of an OTLP/HTTP endpoint to export traces of provider operations and API calls to, for example `http://localhost:4318`. The standard `OTEL_EXPORTER_OTLP_*` environment variables are honored when unset.",
+			},
+			"otlp_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers sent along with exported traces, such as `lightstep-access-token`.",
+			},
+			"https_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of a proxy to reach the Lightstep API through, for example `http://proxy.example.com:3128`. The standard `HTTPS_PROXY` and `NO_PROXY` environment variables are honored when unset.",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("LIGHTSTEP_CA_CERT_FILE", ""),
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM encoded CA certificate bundle trusted in addition to the system roots, e.g. for a TLS-intercepting proxy.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificate bundle trusted in addition to the system roots. Conflicts with `ca_cert_file`.",
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_cert_pem"},
+				RequiredWith:  []string{"client_key_file"},
+				Description:   "Path to a PEM encoded client certificate presented for mutual TLS. Requires `client_key_file`.",
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_key_pem"},
+				RequiredWith:  []string{"client_cert_file"},
+				Description:   "Path to the PEM encoded private key of `client_cert_file`.",
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_cert_file"},
+				RequiredWith:  []string{"client_key_pem"},
+				Description:   "PEM encoded client certificate presented for mutual TLS. Requires `client_key_pem`.",
+			},
+			"client_key_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_key_file"},
+				RequiredWith:  []string{"client_cert_pem"},
+				Description:   "PEM encoded private key of `client_cert_pem`.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip verification of the Lightstep API's TLS certificate. Only meant for debugging; prefer `ca_cert_file`.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"lightstep_stream":                 resourceStream(),
-			"lightstep_stream_dashboard":       resourceStreamDashboard(),
-			"lightstep_stream_condition":       resourceStreamCondition(),
-			"lightstep_metric_condition":       resourceUnifiedCondition(MetricConditionSchema),
-			"lightstep_snooze_rule":            resourceSnoozeRule(),
-			"lightstep_metric_dashboard":       resourceUnifiedDashboard(MetricChartSchema),
-			"lightstep_webhook_destination":    resourceWebhookDestination(),
-			"lightstep_pagerduty_destination":  resourcePagerdutyDestination(),
-			"lightstep_slack_destination":      resourceSlackDestination(),
-			"lightstep_servicenow_destination": resourceServiceNowDestination(),
-			"lightstep_alerting_rule":          resourceAlertingRule(),
-			"lightstep_dashboard":              resourceUnifiedDashboard(UnifiedChartSchema),
-			"lightstep_alert":                  resourceUnifiedCondition(UnifiedConditionSchema),
-			"lightstep_user_role_binding":      resourceUserRoleBinding(),
-			"lightstep_inferred_service_rule":  resourceInferredServiceRule(),
-			"lightstep_saml_group_mappings":    resourceSAMLGroupMappings(),
-			"lightstep_event_query":            resourceEventQuery(),
+			"lightstep_stream":                   resourceStream(),
+			"lightstep_stream_dashboard":         resourceStreamDashboard(),
+			"lightstep_stream_condition":         resourceStreamCondition(),
+			"lightstep_metric_condition":         resourceUnifiedCondition(MetricConditionSchema),
+			"lightstep_snooze_rule":              resourceSnoozeRule(),
+			"lightstep_metric_dashboard":         resourceUnifiedDashboard(MetricChartSchema),
+			"lightstep_webhook_destination":      resourceWebhookDestination(),
+			"lightstep_pagerduty_destination":    resourcePagerdutyDestination(),
+			"lightstep_slack_destination":        resourceSlackDestination(),
+			"lightstep_msteams_destination":      resourceMSTeamsDestination(),
+			"lightstep_email_destination":        resourceEmailDestination(),
+			"lightstep_destination_test":         resourceDestinationTest(),
+			"lightstep_servicenow_destination":   resourceServiceNowDestination(),
+			"lightstep_opsgenie_destination":     resourceOpsgenieDestination(),
+			"lightstep_alerting_rule":            resourceAlertingRule(),
+			"lightstep_dashboard":                resourceUnifiedDashboard(UnifiedChartSchema),
+			"lightstep_alert":                    resourceUnifiedCondition(UnifiedConditionSchema),
+			"lightstep_user_role_binding":        resourceUserRoleBinding(),
+			"lightstep_user_role_binding_member": resourceUserRoleBindingMember(),
+			"lightstep_inferred_service_rule":    resourceInferredServiceRule(),
+			"lightstep_saml_group_mappings":      resourceSAMLGroupMappings(),
+			"lightstep_event_query":              resourceEventQuery(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"lightstep_stream": dataSourceStream(),
+			"lightstep_stream":                   dataSourceStream(),
+			"lightstep_webhook_template_preview": dataSourceWebhookTemplatePreview(),
 		},
 
-		ConfigureContextFunc: configureProvider,
-		TerraformVersion:     "v1.0.3",
+		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return configureProviderWithHooks(ctx, d, hooks)
+		},
+		TerraformVersion: "v1.0.3",
 	}
+
+	for typeName, r := range p.ResourcesMap {
+		withDefaultProject(r, false)
+		instrumentResource(typeName, r)
+	}
+	for typeName, r := range p.DataSourcesMap {
+		withDefaultProject(r, true)
+		instrumentResource(typeName, r)
+	}
+	return p
 }
 
-func configureProvider(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return configureProviderWithHooks(ctx, d, providerHooks{})
+}
+
+func configureProviderWithHooks(ctx context.Context, d *schema.ResourceData, hooks providerHooks) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	var apiKey string
-	apiKey = d.Get("api_key").(string)
-	if len(apiKey) == 0 {
-		envVar := d.Get("api_key_env_var").(string)
-		apiKeyEnv, ok := os.LookupEnv(envVar)
-		if !ok {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "No api key found",
-				Detail:   fmt.Sprintf("'api_key_env_var' is set to %v - but no api key found.", envVar),
-			})
-			return apiKey, diags
-		}
-		apiKey = apiKeyEnv
+
+	creds, err := client.ResolveCredentials(ctx, client.CredentialsConfig{
+		APIKey:        d.Get("api_key").(string),
+		Org:           d.Get("organization").(string),
+		APIURL:        d.Get("api_url").(string),
+		KeepAPIURL:    environmentSet(d),
+		APIKeyCommand: d.Get("api_key_command").(string),
+		APIKeyEnvVar:  d.Get("api_key_env_var").(string),
+		Profile:       d.Get("profile").(string),
+		ConfigFile:    d.Get("config_file").(string),
+	})
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to resolve credentials",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+	if len(creds.APIKey) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "No api key found",
+			Detail:   fmt.Sprintf("Provide the API key with one of, in order of precedence: 'api_key', 'api_key_command', an 'api_key' or 'api_key_command' entry of the profile selected with 'profile', the %v environment variable (see 'api_key_env_var'), or an entry of the default profile.", d.Get("api_key_env_var").(string)),
+		})
+		return nil, diags
+	}
+	if len(creds.Org) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "No organization found",
+			Detail:   "Set 'organization', the LIGHTSTEP_ORG environment variable or 'organization' in the selected profile.",
+		})
+		return nil, diags
 	}
 
 	// TODO remove this code once `environment` is fully deprecated
 	var baseUrl string
-	optionalUrl := d.Get("api_url").(string)
-	if len(optionalUrl) > 0 {
-		baseUrl = optionalUrl
+	if len(creds.APIURL) > 0 {
+		baseUrl = creds.APIURL
 	} else {
 		// get the url from the `environment` provider attribute
 		env := d.Get("environment").(string)
@@ -117,23 +317,242 @@ func configureProvider(_ context.Context, d *schema.ResourceData) (interface{},
 		}
 	}
 
+	// both durations have already been validated
+	retryWaitMin, _ := time.ParseDuration(d.Get("retry_wait_min").(string))
+	retryWaitMax, _ := time.ParseDuration(d.Get("retry_wait_max").(string))
+	if retryWaitMin > retryWaitMax {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid retry configuration",
+			Detail:   fmt.Sprintf("'retry_wait_min' (%v) must not be greater than 'retry_wait_max' (%v).", retryWaitMin, retryWaitMax),
+		})
+		return nil, diags
+	}
+
+	headers := map[string]string{}
+	for k, v := range d.Get("otlp_headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
+	if err := configureTracing(ctx, d.Get("otlp_endpoint").(string), headers); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid tracing configuration",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("otlp_endpoint"),
+		})
+		return nil, diags
+	}
+
+	policy, policyDiags := expandPolicy(d)
+	diags = append(diags, policyDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	transport, transportDiags := configureTransport(d)
+	diags = append(diags, transportDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	opts := []client.Option{
+		client.WithRetryMax(d.Get("max_retries").(int)),
+		client.WithRetryWait(retryWaitMin, retryWaitMax),
+		client.WithReadOnly(d.Get("read_only").(bool)),
+	}
+	if hooks.wrapTransport != nil {
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		transport = hooks.wrapTransport(transport)
+	}
+	if transport != nil {
+		opts = append(opts, client.WithTransport(transport))
+	}
+	opts = append(opts, hooks.clientOptions...)
+
 	client := client.NewClientWithUserAgent(
-		apiKey,
-		d.Get("organization").(string),
+		creds.APIKey,
+		creds.Org,
 		baseUrl,
 		fmt.Sprintf("%s/%s (terraform %s)", "terraform-provider-lightstep", version.ProviderVersion, meta.SDKVersionString()),
+		opts...,
 	)
 
-	return client, diags
+	if d.Get("validate_credentials").(bool) && !skipCredentialsValidation() {
+		diags = append(diags, validateCredentials(ctx, client, d.Get("check_write_permission").(bool), d.Get("project").(string))...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	return &providerMeta{
+		Client:        client,
+		defaultLabels: expandDefaultLabels(d),
+		project:       d.Get("project").(string),
+		policy:        policy,
+	}, diags
 }
 
-func handleAPIError(err error, d *schema.ResourceData, resourceName string) diag.Diagnostics {
-	apiErr, ok := err.(client.APIResponseCarrier)
-	if ok {
-		if apiErr.GetStatusCode() == http.StatusNotFound {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("%s not found: %v", resourceName, apiErr))
+// environmentSet reports whether the deprecated `environment` is set explicitly, in which
+// case it picks the API URL rather than a profile.
+func environmentSet(d *schema.ResourceData) bool {
+	// a value other than the default can only have been set, which also covers
+	// configurations without a raw configuration
+	if os.Getenv("LIGHTSTEP_ENV") != "" || d.Get("environment").(string) != "public" {
+		return true
+	}
+	config := d.GetRawConfig()
+	return !config.IsNull() && config.IsKnown() && !config.GetAttr("environment").IsNull()
+}
+
+// skipCredentialsValidation reports whether LIGHTSTEP_SKIP_CREDENTIALS_VALIDATION overrides
+// `validate_credentials`, so plan-only pipelines can opt out without changing the configuration.
+func skipCredentialsValidation() bool {
+	skip, _ := strconv.ParseBool(os.Getenv("LIGHTSTEP_SKIP_CREDENTIALS_VALIDATION"))
+	return skip
+}
+
+// validateCredentials turns the outcome of a cheap authenticated request into diagnostics
+// that tell an invalid key, an unknown organization and, when checkWrite is set, a read-only
+// key apart. Write permission is checked in defaultProject, or else in the first project.
+func validateCredentials(ctx context.Context, c *client.Client, checkWrite bool, defaultProject string) diag.Diagnostics {
+	project, err := c.CheckCredentials(ctx)
+	switch {
+	case client.IsUnauthorized(err):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid API key",
+			Detail:   fmt.Sprintf("The Lightstep API rejected the API key for organization %q. Check that the key has not expired or been revoked: %v", c.OrgName(), err),
+		}}
+	case client.IsNotFound(err):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unknown organization",
+			Detail:        fmt.Sprintf("Organization %q does not exist. Check 'organization' and 'api_url': %v", c.OrgName(), err),
+			AttributePath: cty.GetAttrPath("organization"),
+		}}
+	case client.IsForbidden(err):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "API key not authorized for organization",
+			Detail:        fmt.Sprintf("The API key does not belong to organization %q: %v", c.OrgName(), err),
+			AttributePath: cty.GetAttrPath("organization"),
+		}}
+	case err != nil:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Failed to validate credentials",
+			Detail:   err.Error(),
+		}}
+	case !checkWrite || (project == nil && defaultProject == "") || c.ReadOnly():
+		// without a project there is nothing to check write permission against, and a
+		// read-only provider doesn't need it
+		return nil
+	}
+
+	projectName := defaultProject
+	if projectName == "" {
+		projectName = project.Attributes.Name
+	}
+	if err := c.CheckWritePermission(ctx, projectName); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "API key lacks write permission",
+			Detail:   fmt.Sprintf("The API key may read organization %q but not change project %q, so applying changes would fail. Use a key with the Member or Admin role: %v", c.OrgName(), projectName, err),
+		}}
+	}
+	return nil
+}
+
+// configureTransport returns the transport described by the proxy and TLS attributes,
+// or nil when none of them is set and the client's default transport is fine.
+func configureTransport(d *schema.ResourceData) (http.RoundTripper, diag.Diagnostics) {
+	var cfg client.TransportConfig
+	var diags diag.Diagnostics
+
+	readPEM := func(fileAttr string, pemAttr string) []byte {
+		if pem := d.Get(pemAttr).(string); pem != "" {
+			return []byte(pem)
+		}
+		path := d.Get(fileAttr).(string)
+		if path == "" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Failed to read '%s'", fileAttr),
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath(fileAttr),
+			})
 		}
+		return data
+	}
+
+	cfg.ProxyURL = d.Get("https_proxy").(string)
+	cfg.CACertPEM = readPEM("ca_cert_file", "ca_cert_pem")
+	cfg.ClientCertPEM = readPEM("client_cert_file", "client_cert_pem")
+	cfg.ClientKeyPEM = readPEM("client_key_file", "client_key_pem")
+	cfg.InsecureSkipVerify = d.Get("insecure_skip_verify").(bool)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if cfg.InsecureSkipVerify {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "TLS certificate verification is disabled",
+			Detail:        "'insecure_skip_verify' is set, so the identity of the Lightstep API is not verified. Use 'ca_cert_file' to trust a custom CA instead.",
+			AttributePath: cty.GetAttrPath("insecure_skip_verify"),
+		})
+	}
+
+	if cfg.ProxyURL == "" && cfg.CACertPEM == nil && cfg.ClientCertPEM == nil && cfg.ClientKeyPEM == nil && !cfg.InsecureSkipVerify {
+		return nil, diags
+	}
+
+	transport, err := client.NewTransport(cfg)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid transport configuration",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+	return transport, diags
+}
+
+func validateDuration(i interface{}, path cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type to be string")
+	}
+	if d, err := time.ParseDuration(v); err != nil || d < 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid duration %q", v),
+			Detail:        "Expected a non-negative duration such as `500ms`, `1s` or `1m`.",
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+func handleAPIError(err error, d *schema.ResourceData, resourceName string) diag.Diagnostics {
+	if client.IsReadOnly(err) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot %s in read-only mode", resourceName),
+			Detail:   fmt.Sprintf("The provider is configured with read_only = true or LIGHTSTEP_READ_ONLY, so no changes are sent to Lightstep: %v", err),
+		}}
+	}
+
+	if client.IsNotFound(err) {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("%s not found: %v", resourceName, err))
 	}
 
 	return diag.FromErr(fmt.Errorf("failed to %s: %v", resourceName, err))
@@ -1,27 +1,32 @@
 package lightstep
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+	"github.com/lightstep/terraform-provider-lightstep/client/cassette"
+	"github.com/lightstep/terraform-provider-lightstep/client/fake"
 )
 
-var testAccProviders map[string]*schema.Provider
-var testAccProvider *schema.Provider
-var testAccProviderFactories map[string]func() (*schema.Provider, error)
 var testProject string
 
 func init() {
-	testAccProvider = Provider()
-	testAccProviders = map[string]*schema.Provider{
-		"lightstep": testAccProvider,
-	}
-
-	testAccProviderFactories = map[string]func() (*schema.Provider, error){
-		"lightstep": func() (*schema.Provider, error) { return Provider(), nil }, //nolint:unparam
-	}
-
 	testProject = os.Getenv("LIGHTSTEP_PROJECT")
 	if testProject == "" {
 		testProject = "terraform-provider-test"
@@ -34,7 +39,320 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_retryConfiguration(t *testing.T) {
+	config := map[string]interface{}{
+		"organization":   "org",
+		"api_key":        "key",
+		"max_retries":    2,
+		"retry_wait_min": "100ms",
+		"retry_wait_max": "5s",
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags := configureProvider(context.Background(), d)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config["retry_wait_min"] = "1m"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Invalid retry configuration", diags[0].Summary)
+
+	assert.True(t, validateDuration("soon", nil).HasError())
+	assert.True(t, validateDuration("-1s", nil).HasError())
+	assert.False(t, validateDuration("1m30s", nil).HasError())
+}
+
+func TestProvider_profiles(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configFile, []byte("[staging]\norganization = staging-org\napi_key = staging-key\n"), 0o600))
+	t.Setenv("LIGHTSTEP_ORG", "")
+
+	config := map[string]interface{}{
+		"config_file":     configFile,
+		"profile":         "staging",
+		"api_key_env_var": "UNSET_LIGHTSTEP_API_KEY",
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags := configureProvider(context.Background(), d)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config["profile"] = "prod"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Failed to resolve credentials", diags[0].Summary)
+
+	config["profile"] = ""
+	config["api_key"] = "key"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "No organization found", diags[0].Summary)
+
+	config["api_key"] = ""
+	config["organization"] = "org"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "No api key found", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "'api_key_command'")
+	assert.Contains(t, diags[0].Detail, "UNSET_LIGHTSTEP_API_KEY")
+}
+
+func TestProvider_profileAPIURL(t *testing.T) {
+	t.Setenv("LS_DISABLE_RATE_LIMIT", "1")
+	t.Setenv("LIGHTSTEP_ENV", "")
+	t.Setenv("LIGHTSTEP_API_URL", "")
+	t.Setenv("LIGHTSTEP_API_BASE_URL", "")
+	configFile := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configFile, []byte("[default]\napi_url = https://api.profile.example.com\n"), 0o600))
+
+	// the host of the credentials check tells which API URL the client uses
+	host := func(config map[string]interface{}) string {
+		var requested string
+		p := newProvider(providerHooks{wrapTransport: func(http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requested = req.URL.Host
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"data": []}`)), Request: req}, nil
+			})
+		}})
+		raw := map[string]interface{}{
+			"organization":         "org",
+			"api_key":              "key",
+			"config_file":          configFile,
+			"max_retries":          0,
+			"validate_credentials": true,
+		}
+		for k, v := range config {
+			raw[k] = v
+		}
+		diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+		require.False(t, diags.HasError(), "%v", diags)
+		return requested
+	}
+
+	assert.Equal(t, "api.profile.example.com", host(nil))
+	assert.Equal(t, "api-staging.lightstep.com", host(map[string]interface{}{"environment": "staging"}))
+	assert.Equal(t, "api.example.com", host(map[string]interface{}{"environment": "staging", "api_url": "https://api.example.com"}))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestProvider_transportConfiguration(t *testing.T) {
+	config := map[string]interface{}{
+		"organization": "org",
+		"api_key":      "key",
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+	transport, diags := configureTransport(d)
+	require.Empty(t, diags)
+	assert.Nil(t, transport)
+
+	config["https_proxy"] = "http://proxy.example.com:3128"
+	config["insecure_skip_verify"] = true
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	transport, diags = configureTransport(d)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "TLS certificate verification is disabled", diags[0].Summary)
+	require.IsType(t, &http.Transport{}, transport)
+	assert.True(t, transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+
+	config["ca_cert_file"] = filepath.Join(t.TempDir(), "missing.pem")
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Failed to read 'ca_cert_file'", diags[0].Summary)
+
+	delete(config, "ca_cert_file")
+	config["ca_cert_pem"] = "not a certificate"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, config)
+	_, diags = configureProvider(context.Background(), d)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[len(diags)-1].Detail, "no valid PEM encoded certificate")
+}
+
+func TestProvider_validateCredentials(t *testing.T) {
+	t.Setenv("LIGHTSTEP_PROJECT", "")
+	t.Setenv("LS_DISABLE_RATE_LIMIT", "1")
+	server := fake.NewServer()
+	defer server.Close()
+	server.Projects = []string{"terraform-provider-tests"}
+
+	configure := func(config map[string]interface{}) diag.Diagnostics {
+		base := map[string]interface{}{
+			"organization":         server.Org,
+			"api_key":              server.APIKey,
+			"api_url":              server.URL,
+			"max_retries":          0,
+			"validate_credentials": true,
+		}
+		for k, v := range config {
+			base[k] = v
+		}
+		return Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(base))
+	}
+
+	diags := configure(nil)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	diags = configure(map[string]interface{}{"api_key": "expired"})
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Invalid API key", diags[0].Summary)
+
+	diags = configure(map[string]interface{}{"organization": "typo-org"})
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Unknown organization", diags[0].Summary)
+
+	// write permission is only probed when asked for
+	server.ReadOnly = true
+	diags = configure(nil)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	diags = configure(map[string]interface{}{"check_write_permission": true})
+	require.True(t, diags.HasError())
+	assert.Equal(t, "API key lacks write permission", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `project "terraform-provider-tests"`)
+
+	t.Setenv("LIGHTSTEP_SKIP_CREDENTIALS_VALIDATION", "true")
+	diags = configure(map[string]interface{}{"api_key": "expired"})
+	require.False(t, diags.HasError(), "%v", diags)
+}
+
+func TestProvider_checkWritePermissionUsesProjectName(t *testing.T) {
+	t.Setenv("LIGHTSTEP_PROJECT", "")
+	t.Setenv("LS_DISABLE_RATE_LIMIT", "1")
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"data": [{"id": "3bd8f1", "type": "project", "attributes": {"name": "production"}}]}`))
+			return
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"organization":           "blars",
+		"api_key":                "api",
+		"api_url":                server.URL,
+		"max_retries":            0,
+		"validate_credentials":   true,
+		"check_write_permission": true,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, deleted, 1)
+	assert.True(t, strings.HasPrefix(deleted[0], "/public/v0.2/blars/projects/production/streams/"), deleted[0])
+
+	// the default project is checked rather than the first one
+	deleted = nil
+	diags = Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"organization":           "blars",
+		"api_key":                "api",
+		"api_url":                server.URL,
+		"max_retries":            0,
+		"validate_credentials":   true,
+		"check_write_permission": true,
+		"project":                "staging",
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, deleted, 1)
+	assert.True(t, strings.HasPrefix(deleted[0], "/public/v0.2/blars/projects/staging/streams/"), deleted[0])
+}
+
+func TestProvider_readOnly(t *testing.T) {
+	t.Setenv("LS_DISABLE_RATE_LIMIT", "1")
+	t.Setenv("LIGHTSTEP_READ_ONLY", "true")
+	server := fake.NewServer()
+	defer server.Close()
+	id := server.Put("offline", "event_queries", fake.Object{
+		"attributes": fake.Object{"name": "deploys", "query_type": "test-type", "source": "test-source", "query_string": "logs"},
+	})
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"organization": server.Org,
+		"api_key":      server.APIKey,
+		"api_url":      server.URL,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	r := p.ResourcesMap["lightstep_event_query"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_name": "offline"})
+	d.SetId(id)
+	require.False(t, r.ReadContext(context.Background(), d, p.Meta()).HasError())
+	assert.Equal(t, "logs", d.Get("query_string"))
+
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "read-only mode is enabled")
+	_, ok := server.Get("offline", "event_queries", id)
+	assert.True(t, ok)
+}
+
+// testFakeAPIProvider returns a provider configured against an in-memory fake of the
+// Lightstep API, for tests that exercise resources without a live organization.
+func testFakeAPIProvider(t *testing.T) (*schema.Provider, *fake.Server) {
+	return testFakeAPIProviderWithConfig(t, nil)
+}
+
+// testFakeAPIProviderWithConfig is testFakeAPIProvider with additional provider attributes.
+func testFakeAPIProviderWithConfig(t *testing.T, config map[string]interface{}) (*schema.Provider, *fake.Server) {
+	t.Setenv("LS_DISABLE_RATE_LIMIT", "1")
+
+	server := fake.NewServer()
+	t.Cleanup(server.Close)
+
+	raw := map[string]interface{}{
+		"organization": server.Org,
+		"api_key":      server.APIKey,
+		"api_url":      server.URL,
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "%v", diags)
+	return p, server
+}
+
+// testResourceDataWithWriteOnly is schema.TestResourceDataRaw for a new resource whose
+// configuration also sets the writeOnly attributes. Like with Terraform, those are only
+// available from the raw configuration, not the resource data.
+func testResourceDataWithWriteOnly(t *testing.T, r *schema.Resource, config map[string]interface{}, writeOnly map[string]interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{}
+	for k, v := range config {
+		raw[k] = v
+	}
+	for k, v := range writeOnly {
+		raw[k] = v
+	}
+	b, err := json.Marshal(raw)
+	require.NoError(t, err)
+	rawConfig, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	// only the state of an existing resource carries a raw configuration
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("new")
+	state := d.State()
+	state.ID = ""
+	state.RawConfig = rawConfig
+	return r.Data(state)
+}
+
 func testAccPreCheck(t *testing.T) {
+	if os.Getenv("LIGHTSTEP_CASSETTE_MODE") == string(cassette.ModeReplay) {
+		return
+	}
+
 	if v := os.Getenv("LIGHTSTEP_API_KEY"); v == "" {
 		t.Fatal("LIGHTSTEP_API_KEY must be set.")
 	}
@@ -42,3 +360,53 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("LIGHTSTEP_ORG must be set")
 	}
 }
+
+// testAccProviderFor returns the provider of the acceptance test t. Checks read the API
+// through its client, so every test gets its own. When LIGHTSTEP_CASSETTE_MODE is set, the
+// API traffic of t is recorded to testdata/cassettes/<test name>.json, or replayed from there
+// without credentials. Tests without a cassette are skipped when replaying.
+func testAccProviderFor(t *testing.T) *schema.Provider {
+	mode := cassette.Mode(os.Getenv("LIGHTSTEP_CASSETTE_MODE"))
+	if mode == "" || os.Getenv("TF_ACC") == "" {
+		return Provider()
+	}
+
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	if _, err := os.Stat(path); mode == cassette.ModeReplay && os.IsNotExist(err) {
+		t.Skipf("no cassette recorded at %s, run make acc-test-record", path)
+	}
+	rec, err := cassette.New(path, mode)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Save(); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+
+	hooks := providerHooks{wrapTransport: rec.Transport}
+	if mode != cassette.ModeReplay {
+		rec.SetOrg(os.Getenv("LIGHTSTEP_ORG"))
+		return newProvider(hooks)
+	}
+
+	// the credentials of the recording aren't needed, nor is throttling
+	hooks.clientOptions = []client.Option{client.WithRateLimit(rate.Inf)}
+	p := newProvider(hooks)
+	p.Schema["api_key"].DefaultFunc = func() (interface{}, error) { return "replayed", nil }
+	p.Schema["organization"].DefaultFunc = func() (interface{}, error) { return rec.Org(), nil }
+	return p
+}
+
+// testAccProviders returns the providers of a resource.TestCase using p.
+func testAccProviders(p *schema.Provider) map[string]*schema.Provider {
+	return map[string]*schema.Provider{"lightstep": p}
+}
+
+// testAccProviderFactories returns the provider factories of a resource.TestCase using p.
+func testAccProviderFactories(p *schema.Provider) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"lightstep": func() (*schema.Provider, error) { return p, nil }, //nolint:unparam
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -101,22 +102,23 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: badAlertMissingQueryAndCompositeFields,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 				),
 				ExpectError: regexp.MustCompile("at least one query is required"),
 			},
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", "metric requests | rate 1h, 30s | filter \"project_name\" == \"catlab\" && \"service\" != \"android\" | group_by[\"method\"], mean | reduce 30s, min"),
@@ -126,7 +128,7 @@ resource "lightstep_alert" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "updated"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a fresh playbook"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", "metric requests | rate 1h, 30s | filter \"project_name\" == \"catlab\" && \"service\" != \"iOS\" | group_by[\"method\"], mean | reduce 30s, min"),
@@ -235,22 +237,23 @@ EOT
 `, uqlQuery)
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: badCondition,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 				),
 				ExpectError: regexp.MustCompile("(Missing required argument|Insufficient metric_query blocks)"),
 			},
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery+"\n"),
@@ -260,7 +263,7 @@ EOT
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "updated"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a fresh playbook"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.is_no_data", "false"),
@@ -350,15 +353,16 @@ EOT
 `, uqlQuery2)
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span latency alert"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery+"\n"),
 				),
@@ -366,7 +370,7 @@ EOT
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span latency alert - updated"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery2+"\n"),
 				),
@@ -455,15 +459,16 @@ EOT
 `, uqlQuery2)
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span rate alert"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery+"\n"),
 				),
@@ -471,7 +476,7 @@ EOT
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery2+"\n"),
 				),
 			},
@@ -526,15 +531,16 @@ EOT
 `, uqlQuery)
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery+"\n"),
 				),
@@ -795,29 +801,30 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: missingBothSingleAndCompositeFieldsCondition,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 				),
 				ExpectError: regexp.MustCompile("at least one query is required"),
 			},
 			{
 				Config: includesBothSingleAndCompositeFieldsCondition,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 				),
 				ExpectError: regexp.MustCompile("single alert queries and composite alert cannot both be set"),
 			},
 			{
 				Config: compositeConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests & customers"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.0.query.0.query_string", "metric requests | rate 1h, 30s | filter \"project_name\" == \"catlab\" && \"service\" != \"android\" | group_by[\"method\"], mean | reduce 30s, min"),
@@ -831,7 +838,7 @@ resource "lightstep_alert" "test" {
 			{
 				Config: updatedCompositeConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "updated too many requests & customers"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.0.query.0.query_string", "metric requests | rate 1h, 30s | filter \"project_name\" == \"catlab\" && \"service\" != \"iOS\" | group_by[\"method\"], mean | reduce 30s, min"),
@@ -843,7 +850,7 @@ resource "lightstep_alert" "test" {
 			{
 				Config: noDataCompositeConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "sub-alert A has no thresholds"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.0.name", "C"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.0.expression.0.is_no_data", "false"),
@@ -861,7 +868,7 @@ resource "lightstep_alert" "test" {
 	})
 }
 
-func testAccCheckLightstepAlertExists(resourceName string, condition *client.UnifiedCondition) resource.TestCheckFunc {
+func testAccCheckLightstepAlertExists(p *schema.Provider, resourceName string, condition *client.UnifiedCondition) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfCondition, ok := s.RootModule().Resources[resourceName]
 		if !ok {
@@ -872,7 +879,7 @@ func testAccCheckLightstepAlertExists(resourceName string, condition *client.Uni
 			return fmt.Errorf("id is not set")
 		}
 
-		providerClient := testAccProvider.Meta().(*client.Client)
+		providerClient := p.Meta().(*providerMeta).Client
 		cond, err := providerClient.GetUnifiedCondition(context.Background(), testProject, tfCondition.Primary.ID)
 		if err != nil {
 			return err
@@ -919,15 +926,16 @@ resource "lightstep_alert" "test" {
 }
 `
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: emptyUpdateIntervalConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests & customers"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "alerting_rule.0.update_interval", ""),
@@ -1065,16 +1073,17 @@ resource "lightstep_alert" "composite_diff_test" {
 `
 
 	resourceName := "lightstep_alert.composite_diff_test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Destroy: false,
 				Config:  initialConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Composite Alert Diff"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.#", "2"),
@@ -1090,7 +1099,7 @@ resource "lightstep_alert" "composite_diff_test" {
 				Config:  changeSubAlertQuery,
 				Destroy: false,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Composite Alert Diff (updated query)"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.#", "2"),
@@ -1106,7 +1115,7 @@ resource "lightstep_alert" "composite_diff_test" {
 				Config:  changeSubAlertName,
 				Destroy: false,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &compositeCondition),
+					testAccCheckLightstepAlertExists(p, resourceName, &compositeCondition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Composite Alert Diff (updated sub-alert name)"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "composite_alert.0.alert.#", "2"),
@@ -1153,15 +1162,16 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.no_data_duration_ms", "60000"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.thresholds.0.warning_duration_ms", "120000"),
@@ -1201,15 +1211,16 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.no_data_duration_ms", "60000"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.thresholds.0.warning_duration_ms", "120000"),
@@ -1248,15 +1259,16 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.no_data_duration_ms", "60000"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.thresholds.0.critical_duration_ms", "180000"),
@@ -1295,15 +1307,16 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "hidden queries spurious diff"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.hidden_queries.%", "1"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.hidden_queries.b", "true"),
@@ -1367,21 +1380,22 @@ resource "lightstep_alert" "test" {
 `
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(conditionConfig, "false"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 				),
 			},
 			{
 				Config: fmt.Sprintf(conditionConfig, "true"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLightstepAlertExists(resourceName, &condition),
+					testAccCheckLightstepAlertExists(p, resourceName, &condition),
 				),
 			},
 		},
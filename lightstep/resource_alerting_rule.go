@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -53,7 +52,7 @@ func resourceAlertingRule() *schema.Resource {
 func resourceAlertingRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 
 	updateIntervalMS := validUpdateInterval[d.Get("update_interval").(string)]
 
@@ -78,16 +77,10 @@ func resourceAlertingRuleCreate(ctx context.Context, d *schema.ResourceData, m i
 func resourceAlertingRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	rule, err := c.GetAlertingRule(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get alerting rule: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
@@ -105,7 +98,7 @@ func resourceAlertingRuleRead(ctx context.Context, d *schema.ResourceData, m int
 func resourceAlertingRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 	if err := client.DeleteAlertingRule(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete alerting rule: %v", err))
 	}
@@ -114,7 +107,7 @@ func resourceAlertingRuleDelete(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceAlertingRuleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
@@ -10,6 +10,7 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -222,21 +223,22 @@ resource "lightstep_dashboard" "test" {
 
 	resourceName := "lightstep_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: badDashboard,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard)),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard)),
 				ExpectError: regexp.MustCompile("The argument \"type\" is required, but no definition was found."),
 			},
 			{
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.name", "Chart Number One"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.rank", "1"),
@@ -248,7 +250,7 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: queryDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.query_string", "metric m | rate"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.display", "line"),
@@ -258,14 +260,14 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: updatedTitleDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard Updated"),
 				),
 			},
 			{
 				Config: dependencyMapDashboard,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.query_string", "spans_sample service = apache | assemble"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.display", "dependency_map"),
@@ -277,7 +279,7 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: groupedDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_description", "Dashboard to test if the terraform provider works"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.title", "Title"),
@@ -291,7 +293,7 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: positionallyGroupedImplicitDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_description", "Dashboard to test if the terraform provider works"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.title", ""),
@@ -306,7 +308,7 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: positionallyGroupedExplicitDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_description", "Dashboard to test if the terraform provider works"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.title", "Title"),
@@ -416,21 +418,22 @@ EOT
 
 	resourceName := "lightstep_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: badDashboard,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard)),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard)),
 				ExpectError: regexp.MustCompile("The argument \"type\" is required, but no definition was found."),
 			},
 			{
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard"),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_description", "Dashboard to test if the terraform provider works"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.name", "Chart Number One"),
@@ -442,7 +445,7 @@ EOT
 			{
 				Config: updatedTitleDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard Updated"),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_description", "Dashboard to test if the terraform provider still works"),
 				),
@@ -501,15 +504,16 @@ resource "lightstep_dashboard" "labels" {
 
 	resourceName := "lightstep_dashboard.labels"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: baseConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "label.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "label.0.key", "team"),
 					resource.TestCheckResourceAttr(resourceName, "label.0.value", "ontology"),
@@ -518,7 +522,7 @@ resource "lightstep_dashboard" "labels" {
 			{
 				Config: updatedConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "label.#", "0"),
 				),
 			},
@@ -576,15 +580,16 @@ resource "lightstep_dashboard" "test" {
 	var dashboard client.UnifiedDashboard
 	resourceName := "lightstep_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: validDashboardConfigWithTemplateVariables,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "Acceptance Test Dashboard with Template Variables"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.query_string", "metric m | filter (service == $service) | rate | group_by [], sum"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.display", "line"),
@@ -597,7 +602,7 @@ resource "lightstep_dashboard" "test" {
 			{
 				Config: invalidDashboardConfigWithInvalidTemplateVariableName,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard)),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard)),
 				ExpectError: regexp.MustCompile("InvalidArgument"),
 			},
 		},
@@ -605,6 +610,7 @@ resource "lightstep_dashboard" "test" {
 }
 
 func TestAccDashboardHiddenQueries(t *testing.T) {
+	p := testAccProviderFor(t)
 
 	queryString := strings.TrimSpace(`
 with
@@ -645,13 +651,13 @@ EOT
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.query_string", queryString+"\n"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.display", "line"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.hidden", "false"),
@@ -663,24 +669,26 @@ EOT
 	})
 }
 
-func testGetMetricDashboardDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "metric_alert" {
-			continue
-		}
+func testGetMetricDashboardDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "metric_alert" {
+				continue
+			}
 
-		s, err := conn.GetUnifiedDashboard(context.Background(), testProject, r.Primary.ID)
-		if err == nil {
-			if s.ID == r.Primary.ID {
-				return fmt.Errorf("metric dashboard with ID (%v) still exists.", r.Primary.ID)
+			s, err := conn.GetUnifiedDashboard(context.Background(), testProject, r.Primary.ID)
+			if err == nil {
+				if s.ID == r.Primary.ID {
+					return fmt.Errorf("metric dashboard with ID (%v) still exists.", r.Primary.ID)
+				}
 			}
 		}
+		return nil
 	}
-	return nil
 }
 
-func testAccCheckMetricDashboardExists(resourceName string, dashboard *client.UnifiedDashboard) resource.TestCheckFunc {
+func testAccCheckMetricDashboardExists(p *schema.Provider, resourceName string, dashboard *client.UnifiedDashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfDashboard, ok := s.RootModule().Resources[resourceName]
 		if !ok {
@@ -691,7 +699,7 @@ func testAccCheckMetricDashboardExists(resourceName string, dashboard *client.Un
 			return fmt.Errorf("id is not set")
 		}
 
-		c := testAccProvider.Meta().(*client.Client)
+		c := p.Meta().(*providerMeta).Client
 		dash, err := c.GetUnifiedDashboard(context.Background(), testProject, tfDashboard.Primary.ID)
 		if err != nil {
 			return err
@@ -738,15 +746,16 @@ resource "lightstep_dashboard" "test_implicit_group" {
 
 	resourceName := "lightstep_dashboard.test_implicit_group"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: baseConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test legacy implicit groups"),
 					resource.TestCheckResourceAttr(resourceName, "chart.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
@@ -861,15 +870,16 @@ resource "lightstep_dashboard" "test_dash" {
 
 	resourceName := "lightstep_dashboard.test_dash"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: baseConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test dash"),
 					resource.TestCheckResourceAttr(resourceName, "chart.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
@@ -884,7 +894,7 @@ resource "lightstep_dashboard" "test_dash" {
 			{
 				Config: updatedConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test dash"),
 					resource.TestCheckResourceAttr(resourceName, "chart.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
@@ -987,14 +997,15 @@ chart {
 }
 
 func TestDisplayTypeOptionsError(t *testing.T) {
+	p := testAccProviderFor(t)
 	var dashboard client.UnifiedDashboard
 
 	resourceName := "lightstep_dashboard.test_display_type_options"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: makeDisplayTypeConfig("table", strings.TrimSpace(`
@@ -1003,7 +1014,7 @@ func TestDisplayTypeOptionsError(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 				),
 				ExpectError: regexp.MustCompile("Unsupported argument"),
 			},
@@ -1012,19 +1023,20 @@ func TestDisplayTypeOptionsError(t *testing.T) {
 }
 
 func TestDisplayTypeOptions(t *testing.T) {
+	p := testAccProviderFor(t)
 	var dashboard client.UnifiedDashboard
 
 	resourceName := "lightstep_dashboard.test_display_type_options"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: makeDisplayTypeConfig("line", ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "line"),
 				),
@@ -1036,7 +1048,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "ordered_list"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.sort_direction", "asc"),
@@ -1050,7 +1062,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "pie"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.is_donut", "true"),
@@ -1065,7 +1077,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "table"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.sort_direction", "desc"),
@@ -1079,7 +1091,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "table"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.y_axis_scale", "log"),
@@ -1093,7 +1105,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "table"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.y_axis_scale", "log"),
@@ -1108,7 +1120,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "table"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.y_axis_min", "0"),
@@ -1118,7 +1130,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 			{
 				Config: makeTrichartDisplay(),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test trichart"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "trichart"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.display_type", "trichart"),
@@ -1135,7 +1147,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "dependency_map"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display_type_options.0.comparison_window_ms", "86400000"),
@@ -1148,7 +1160,7 @@ func TestDisplayTypeOptions(t *testing.T) {
 	}
 `)),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test display_type_options"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.0.display", "line"),
 				),
@@ -1221,16 +1233,17 @@ resource "lightstep_dashboard" "test_text_panels" {
 	`, body) //, resourceName, extra)
 	}
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Check an invalid text_panel property
 				Config: makeTextPanelTestConfig("", "nonsense = \"true\""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 				),
 				ExpectError: regexp.MustCompile("Unsupported argument"),
 			},
@@ -1238,7 +1251,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 				// Rank is not a property of text_panel (explicit position is used instead)
 				Config: makeTextPanelTestConfig("", "rank = 0"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 				),
 				ExpectError: regexp.MustCompile("Unsupported argument"),
 			},
@@ -1246,7 +1259,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 				// Check the base config
 				Config: makeTextPanelTestConfig("", ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.0.name", "Don't panic 😅"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.0.text", "# Hello **world**...?"),
@@ -1257,7 +1270,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 				// Update with a chart
 				Config: makeTextPanelTestConfig(chartDescriptor, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.0.text", "# Hello **world**...?"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.1.text", "## Hello world"),
@@ -1273,7 +1286,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 		height = 4
 `),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.0.text", "# Hello **world**...?"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.1.text", "## Hello world"),
@@ -1285,7 +1298,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 				// Update with a long text string
 				Config: strings.Replace(makeTextPanelTestConfig("", ""), "## Hello world", longText, 1),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.0.text", "# Hello **world**...?"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.1.text", longText),
@@ -1305,7 +1318,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 	}
 				`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.text_panel.#", "1"),
@@ -1334,7 +1347,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 	}
 				`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "group.*", map[string]string{
 						"text_panel.#":      "1",
@@ -1378,7 +1391,7 @@ resource "lightstep_dashboard" "test_text_panels" {
 	}
 				`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "dashboard_name", "test_text_panels"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "group.*", map[string]string{
 						"text_panel.#":      "2",
@@ -1416,16 +1429,17 @@ resource "lightstep_dashboard" "test_text_panels" {
 `
 	}
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Check an invalid text_panel property
 				Config: makeTextPanelTestConfig(),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 				),
 				ExpectError: regexp.MustCompile("Unsupported block type"),
 			},
@@ -1469,16 +1483,17 @@ group {
 }
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// empty subtitle
 				Config: fmt.Sprintf(configTemplate, `subtitle = ""`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.subtitle", ""),
@@ -1488,7 +1503,7 @@ group {
 				// normal subtitle
 				Config: fmt.Sprintf(configTemplate, `subtitle = "percent"`),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.subtitle", "percent"),
@@ -1537,15 +1552,16 @@ group {
 }
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: configTemplate,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.query.#", "1"),
@@ -1589,15 +1605,16 @@ EOT
 
 	resourceName := "lightstep_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: dashboardWithLongQueryName,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard)),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard)),
 				ExpectError: regexp.MustCompile("expected length of .*\\.query_name to be in the range \\(1 - \\d+\\), got " + longName),
 			},
 		},
@@ -1605,6 +1622,7 @@ EOT
 }
 
 func TestAccDashboardHiddenQueriesWithOuterQueryHidden(t *testing.T) {
+	p := testAccProviderFor(t)
 	config := `
 resource "lightstep_dashboard" "test" {
 	project_name          = "` + testProject + `"
@@ -1629,13 +1647,13 @@ resource "lightstep_dashboard" "test" {
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "chart.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.query.0.hidden", "false"),
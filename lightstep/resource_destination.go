@@ -3,48 +3,142 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lightstep/terraform-provider-lightstep/client"
 )
 
-func errorIsNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-	apiErr, ok := err.(client.APIResponseCarrier)
-	if !ok {
-		return false
-	}
-	resp := apiErr.GetHTTPResponse()
-	if resp == nil {
-		return false
-	}
-	return resp.StatusCode == http.StatusNotFound
-}
-
 // these are common across all types of destinations
 func resourceDestinationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	dest, err := c.GetDestination(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		if errorIsNotFound(err) {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diag.Diagnostics{}
 		}
 		return diag.FromErr(fmt.Errorf("failed to get destination: %v", err))
 	}
 	d.SetId(dest.ID)
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return diag.FromErr(err)
+	}
 	return diag.Diagnostics{}
 }
 
+// setDestinationAttributes sets the resource fields of a destination read from the API,
+// so changes made outside of Terraform show up as a diff.
+func setDestinationAttributes(d *schema.ResourceData, dest *client.Destination) error {
+	switch attrs := dest.Attributes.(type) {
+	case client.WebhookAttributes:
+		if err := d.Set("destination_name", attrs.Name); err != nil {
+			return fmt.Errorf("unable to set destination_name resource field: %v", err)
+		}
+		if err := d.Set("url", attrs.URL); err != nil {
+			return fmt.Errorf("unable to set url resource field: %v", err)
+		}
+		if err := d.Set("template", attrs.Template); err != nil {
+			return fmt.Errorf("unable to set template resource field: %v", err)
+		}
+		// auth headers are managed by auth_header, whose values are write-only
+		headers := map[string]interface{}{}
+		for name, value := range attrs.CustomHeaders {
+			if !isWebhookAuthHeader(d, name) {
+				headers[name] = value
+			}
+		}
+		if err := d.Set("custom_headers", headers); err != nil {
+			return fmt.Errorf("unable to set custom_headers resource field: %v", err)
+		}
+	case client.PagerdutyAttributes:
+		if err := d.Set("destination_name", attrs.Name); err != nil {
+			return fmt.Errorf("unable to set destination_name resource field: %v", err)
+		}
+		if err := d.Set("integration_key", attrs.IntegrationKey); err != nil {
+			return fmt.Errorf("unable to set integration_key resource field: %v", err)
+		}
+	case client.SlackAttributes:
+		if err := d.Set("channel", attrs.Channel); err != nil {
+			return fmt.Errorf("unable to set channel resource field: %v", err)
+		}
+	case client.ServiceNowAttributes:
+		if err := d.Set("destination_name", attrs.Name); err != nil {
+			return fmt.Errorf("unable to set destination_name resource field: %v", err)
+		}
+		if err := d.Set("url", attrs.URL); err != nil {
+			return fmt.Errorf("unable to set url resource field: %v", err)
+		}
+		// the API never returns the password, so keep the one from the configuration
+		auth := map[string]interface{}{
+			"username":            attrs.Auth.Username,
+			"password":            d.Get("auth.0.password").(string),
+			"password_wo_version": d.Get("auth.0.password_wo_version").(int),
+		}
+		if err := d.Set("auth", []interface{}{auth}); err != nil {
+			return fmt.Errorf("unable to set auth resource field: %v", err)
+		}
+	case client.EmailAttributes:
+		if err := d.Set("destination_name", attrs.Name); err != nil {
+			return fmt.Errorf("unable to set destination_name resource field: %v", err)
+		}
+		if err := d.Set("recipients", attrs.Recipients); err != nil {
+			return fmt.Errorf("unable to set recipients resource field: %v", err)
+		}
+		if err := d.Set("subject_template", attrs.SubjectTemplate); err != nil {
+			return fmt.Errorf("unable to set subject_template resource field: %v", err)
+		}
+	case client.MSTeamsAttributes:
+		if err := d.Set("destination_name", attrs.Name); err != nil {
+			return fmt.Errorf("unable to set destination_name resource field: %v", err)
+		}
+		if err := d.Set("url", attrs.URL); err != nil {
+			return fmt.Errorf("unable to set url resource field: %v", err)
+		}
+		if err := d.Set("template", attrs.Template); err != nil {
+			return fmt.Errorf("unable to set template resource field: %v", err)
+		}
+	case client.OpsgenieAttributes:
+		return setOpsgenieAttributes(d, attrs)
+	default:
+		return fmt.Errorf("unexpected attributes for destination %s: %T", dest.ID, dest.Attributes)
+	}
+	return nil
+}
+
+// writeOnlyString returns the value of a write-only attribute, which is only available in
+// the configuration. It's empty when the attribute isn't set.
+func writeOnlyString(d *schema.ResourceData, path cty.Path) (string, diag.Diagnostics) {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() {
+		return "", diags
+	}
+	if !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return v.AsString(), nil
+}
+
+// updateDestination replaces the destination's attributes in place, so its ID and the
+// alerting rules referencing it are kept.
+func updateDestination(ctx context.Context, d *schema.ResourceData, m interface{}, attrs interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	_, err := c.UpdateDestination(ctx, d.Get("project_name").(string), d.Id(), client.Destination{
+		Type:       "destination",
+		Attributes: attrs,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to update destination: %v", err))
+	}
+	return resourceDestinationRead(ctx, d, m)
+}
+
 func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 	if err := client.DeleteDestination(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete destination: %v", err))
 	}
@@ -52,10 +146,11 @@ func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, m in
 	return diags
 }
 
-func splitID(id string) ([]string, error) {
+// splitID splits the import ID of a destination of the given resource type into its project and ID.
+func splitID(resourceType string, id string) ([]string, error) {
 	ids := strings.Split(id, ".")
 	if len(ids) != 2 {
-		return nil, fmt.Errorf("error importing lightstep_pagerduty_destination. Expecting an ID formed as '<lightstep_project>.<lightstep_destination_ID>'")
+		return nil, fmt.Errorf("error importing %s. Expecting an ID formed as '<lightstep_project>.<lightstep_destination_ID>'", resourceType)
 	}
 	return ids, nil
 }
@@ -0,0 +1,170 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDestinations_fakeAPI creates each destination against the fake API, changes it outside of
+// Terraform, reads the change back and imports it.
+func TestDestinations_fakeAPI(t *testing.T) {
+	tests := []struct {
+		resource        string
+		destinationType string
+		config          map[string]interface{}
+		// checkStored checks the attributes sent to the API
+		checkStored func(t *testing.T, attributes map[string]interface{})
+		// change edits the stored attributes and changed is the attribute it shows up in
+		change  func(attributes map[string]interface{})
+		changed string
+		// imported are the values expected after importing the destination
+		imported map[string]interface{}
+	}{
+		{
+			resource:        "lightstep_opsgenie_destination",
+			destinationType: "opsgenie",
+			config: map[string]interface{}{
+				"project_name":     "offline",
+				"destination_name": "on-call",
+				"api_key":          "opsgenie-key",
+				"region":           "eu",
+				"responder": []interface{}{
+					map[string]interface{}{"type": "team", "name": "checkout"},
+					map[string]interface{}{"type": "user", "name": "jane@example.com"},
+				},
+				"priority_mapping": []interface{}{map[string]interface{}{
+					"warning": "P4",
+				}},
+			},
+			checkStored: func(t *testing.T, attributes map[string]interface{}) {
+				assert.Equal(t, "opsgenie-key", attributes["api_key"])
+				assert.Equal(t, map[string]interface{}{"warning": "P4", "critical": "P1"}, attributes["priority_mapping"])
+				assert.Len(t, attributes["responders"], 2)
+			},
+			change: func(attributes map[string]interface{}) {
+				attributes["region"] = "us"
+			},
+			changed: "region",
+			imported: map[string]interface{}{
+				"destination_name":            "on-call",
+				"api_key":                     "opsgenie-key",
+				"priority_mapping.0.warning":  "P4",
+				"priority_mapping.0.critical": "P1",
+			},
+		},
+		{
+			resource:        "lightstep_msteams_destination",
+			destinationType: "msteams",
+			config: map[string]interface{}{
+				"project_name":     "offline",
+				"destination_name": "incidents",
+				"url":              "https://example.webhook.office.com/webhookb2/abc",
+				"template":         `{"type": "AdaptiveCard", "body": [{"type": "TextBlock", "text": "alert"}]}`,
+			},
+			checkStored: func(t *testing.T, attributes map[string]interface{}) {
+				assert.Equal(t, "https://example.webhook.office.com/webhookb2/abc", attributes["url"])
+			},
+			change: func(attributes map[string]interface{}) {
+				delete(attributes, "template")
+			},
+			changed: "template",
+			imported: map[string]interface{}{
+				"destination_name": "incidents",
+				"url":              "https://example.webhook.office.com/webhookb2/abc",
+			},
+		},
+		{
+			resource:        "lightstep_email_destination",
+			destinationType: "email",
+			config: map[string]interface{}{
+				"project_name":     "offline",
+				"destination_name": "low severity",
+				"recipients":       []interface{}{"oncall@example.com", "sre@example.com"},
+				"subject_template": "[{{.Status}}] {{.AlertName}}",
+			},
+			checkStored: func(t *testing.T, attributes map[string]interface{}) {
+				assert.ElementsMatch(t, []interface{}{"oncall@example.com", "sre@example.com"}, attributes["recipients"])
+			},
+			change: func(attributes map[string]interface{}) {
+				attributes["recipients"] = []interface{}{"oncall@example.com"}
+			},
+			changed: "recipients.#",
+			imported: map[string]interface{}{
+				"destination_name": "low severity",
+				"subject_template": "[{{.Status}}] {{.AlertName}}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			p, server := testFakeAPIProvider(t)
+			r := p.ResourcesMap[tt.resource]
+			ctx := context.Background()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.config)
+			require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+			stored, ok := server.Get("offline", "destinations", d.Id())
+			require.True(t, ok)
+			attributes := stored["attributes"].(map[string]interface{})
+			assert.Equal(t, tt.destinationType, attributes["destination_type"])
+			tt.checkStored(t, attributes)
+
+			diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(tt.config), p.Meta())
+			require.NoError(t, err)
+			assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+			// changes made outside of Terraform are detected
+			tt.change(attributes)
+			server.Put("offline", "destinations", stored)
+
+			require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+			diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(tt.config), p.Meta())
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+			assert.Contains(t, diff.Attributes, tt.changed)
+			assert.False(t, diff.RequiresNew())
+
+			imported := r.Data(nil)
+			imported.SetId("offline." + d.Id())
+			states, err := r.Importer.StateContext(ctx, imported, p.Meta())
+			require.NoError(t, err)
+			require.Len(t, states, 1)
+			for key, want := range tt.imported {
+				assert.Equal(t, want, states[0].Get(key), key)
+			}
+
+			malformed := r.Data(nil)
+			malformed.SetId("offline." + d.Id() + ".extra")
+			_, err = r.Importer.StateContext(ctx, malformed, p.Meta())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "error importing "+tt.resource+".")
+		})
+	}
+}
+
+func TestDestinationImport_malformedID(t *testing.T) {
+	p, _ := testFakeAPIProvider(t)
+	for _, resource := range []string{
+		"lightstep_opsgenie_destination",
+		"lightstep_msteams_destination",
+		"lightstep_email_destination",
+		"lightstep_servicenow_destination",
+		"lightstep_webhook_destination",
+	} {
+		t.Run(resource, func(t *testing.T) {
+			r := p.ResourcesMap[resource]
+			d := r.Data(nil)
+			d.SetId("offline.abc.extra")
+			_, err := r.Importer.StateContext(context.Background(), d, p.Meta())
+			require.Error(t, err)
+			assert.Equal(t, "error importing "+resource+". Expecting an ID formed as '<lightstep_project>.<lightstep_destination_ID>'", err.Error())
+		})
+	}
+}
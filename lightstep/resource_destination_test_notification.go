@@ -0,0 +1,89 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDestinationTest sends a test notification to a destination whenever it's created,
+// which happens again each time one of its triggers changes.
+func resourceDestinationTest() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Sends a test notification to a destination and fails the apply when it isn't delivered. A new notification is sent whenever `destination_id` or `triggers` change.",
+		CreateContext: resourceDestinationTestCreate,
+		ReadContext:   resourceDestinationTestRead,
+		DeleteContext: resourceDestinationTestDelete,
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Lightstep project name",
+			},
+			"destination_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the destination to send the test notification to",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that send a new test notification when changed. Reference attributes of the destination to test it each time it changes",
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "HTTP status code the destination responded with to the test notification",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Response of the destination to the test notification",
+			},
+		},
+	}
+}
+
+func resourceDestinationTestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	destinationID := d.Get("destination_id").(string)
+
+	result, err := c.TestDestination(ctx, d.Get("project_name").(string), destinationID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to send test notification to destination %s: %v", destinationID, err))
+	}
+	if !result.Delivered {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Test notification was not delivered",
+			Detail:   fmt.Sprintf("Destination %s responded with status code %d: %s", destinationID, result.StatusCode, result.Message),
+		}}
+	}
+
+	d.SetId(resource.UniqueId())
+	if err := d.Set("status_code", result.StatusCode); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to set status_code resource field: %v", err))
+	}
+	if err := d.Set("message", result.Message); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to set message resource field: %v", err))
+	}
+	return nil
+}
+
+// resourceDestinationTestRead keeps the outcome of the test notification in the state; there
+// is nothing to read back.
+func resourceDestinationTestRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceDestinationTestDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
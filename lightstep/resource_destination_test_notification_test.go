@@ -0,0 +1,80 @@
+package lightstep
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lightstep/terraform-provider-lightstep/client/fake"
+)
+
+func TestDestinationTest_fakeAPI(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_destination_test"]
+	ctx := context.Background()
+
+	var sent []string
+	server.TestDestination = func(destination fake.Object) (int, string) {
+		sent = append(sent, destination["id"].(string))
+		if destination["attributes"].(map[string]interface{})["url"] == "https://example.com/gone" {
+			return http.StatusGone, "Gone"
+		}
+		return http.StatusOK, "OK"
+	}
+
+	destination := p.ResourcesMap["lightstep_webhook_destination"]
+	hook := schema.TestResourceDataRaw(t, destination.Schema, map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "hook",
+		"url":              "https://example.com/hook",
+	})
+	require.False(t, destination.CreateContext(ctx, hook, p.Meta()).HasError())
+
+	config := map[string]interface{}{
+		"project_name":   "offline",
+		"destination_id": hook.Id(),
+		"triggers":       map[string]interface{}{"url": "https://example.com/hook"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, 200, d.Get("status_code"))
+	assert.Equal(t, []string{hook.Id()}, sent)
+
+	// a changed trigger sends a new notification
+	config["triggers"] = map[string]interface{}{"url": "https://example.com/gone"}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+
+	// and an undelivered notification fails with the destination's response
+	gone := schema.TestResourceDataRaw(t, destination.Schema, map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "gone",
+		"url":              "https://example.com/gone",
+	})
+	require.False(t, destination.CreateContext(ctx, gone, p.Meta()).HasError())
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_name":   "offline",
+		"destination_id": gone.Id(),
+	})
+	diags := r.CreateContext(ctx, d, p.Meta())
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Test notification was not delivered", diags[0].Summary)
+	assert.Equal(t, "Destination "+gone.Id()+" responded with status code 410: Gone", diags[0].Detail)
+	assert.Empty(t, d.Id())
+
+	// as does a destination that doesn't exist
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_name":   "offline",
+		"destination_id": "missing",
+	})
+	assert.True(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+}
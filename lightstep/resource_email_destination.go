@@ -0,0 +1,126 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceEmailDestination() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEmailDestinationCreate,
+		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceEmailDestinationUpdate,
+		DeleteContext: resourceDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEmailDestinationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Lightstep project name",
+			},
+			"destination_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Lightstep destination name",
+			},
+			"recipients": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateEmailAddress,
+				},
+				Description: "Email addresses notified of alerts, such as `oncall@example.com`",
+			},
+			"subject_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template of the email subject. Lightstep's default subject is used when unset",
+			},
+		},
+	}
+}
+
+func validateEmailAddress(i interface{}, path cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type to be string")
+	}
+	// only bare addresses are accepted, not "Name <address>"
+	if addr, err := mail.ParseAddress(v); err != nil || addr.Address != v {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid email address %q", v),
+			Detail:        "Expected a single address such as `oncall@example.com`.",
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+func resourceEmailDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	attrs := buildEmailAttributes(d)
+	dest := client.Destination{
+		Type:       "destination",
+		Attributes: attrs,
+	}
+
+	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string), dest)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create email destination %v: %v", attrs.Name, err))
+	}
+
+	d.SetId(destination.ID)
+	return resourceDestinationRead(ctx, d, m)
+}
+
+func resourceEmailDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return updateDestination(ctx, d, m, buildEmailAttributes(d))
+}
+
+func buildEmailAttributes(d *schema.ResourceData) client.EmailAttributes {
+	return client.EmailAttributes{
+		Name:            d.Get("destination_name").(string),
+		DestinationType: "email",
+		Recipients:      buildStringSlice(d.Get("recipients").(*schema.Set).List()),
+		SubjectTemplate: d.Get("subject_template").(string),
+	}
+}
+
+func resourceEmailDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*providerMeta).Client
+
+	ids, err := splitID("lightstep_email_destination", d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	project, id := ids[0], ids[1]
+	dest, err := c.GetDestination(ctx, project, id)
+	if err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("failed to get email destination: %v", err)
+	}
+
+	d.SetId(dest.ID)
+	if err := d.Set("project_name", project); err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
+	}
+
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,29 @@
+package lightstep
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailDestination_validation(t *testing.T) {
+	r := resourceEmailDestination()
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "list",
+		"recipients":       []interface{}{"oncall@example.com", "Ops <ops@example.com>", "not-an-address"},
+	}))
+	require.Len(t, diags, 2)
+	assert.Equal(t, `invalid email address "Ops <ops@example.com>"`, diags[0].Summary)
+	assert.Equal(t, `invalid email address "not-an-address"`, diags[1].Summary)
+
+	diags = r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "list",
+		"recipients":       []interface{}{"oncall@example.com"},
+	}))
+	assert.False(t, diags.HasError(), "%v", diags)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/lightstep/terraform-provider-lightstep/client"
@@ -62,19 +61,15 @@ func resourceEventQuery() *schema.Resource {
 
 func resourceEventQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	eq, err := c.GetEventQuery(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get event query: %v", err))
-		}
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
-		return diag.FromErr(fmt.Errorf("failed to get event query: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get event query: %v", err))
 	}
 
 	if err := d.Set("name", eq.Name); err != nil {
@@ -111,7 +106,7 @@ func resourceDataToStringSlice(resourceData *schema.ResourceData, fieldName stri
 }
 
 func resourceEventQueryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	attrs := client.EventQueryAttributes{
 		Type:          d.Get("type").(string),
@@ -131,7 +126,7 @@ func resourceEventQueryCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceEventQueryImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
@@ -170,7 +165,7 @@ func resourceEventQueryImport(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceEventQueryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	attrs := client.EventQueryAttributes{
 		Type:          d.Get("type").(string),
@@ -192,7 +187,7 @@ func resourceEventQueryUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourceEventQueryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 	if err := client.DeleteEventQuery(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete event query: %v", err))
 	}
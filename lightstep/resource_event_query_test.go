@@ -3,10 +3,15 @@ package lightstep
 import (
 	"context"
 	"fmt"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/lightstep/terraform-provider-lightstep/client"
-	"testing"
 )
 
 func TestAccEventQuery(t *testing.T) {
@@ -34,15 +39,16 @@ resource "lightstep_event_query" "terraform" {
   description = "this is one heck of a query"
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccEventQueryDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccEventQueryDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: eventQueryConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckEventQueryExists("lightstep_event_query.terraform", &eventQuery),
+					testAccCheckEventQueryExists(p, "lightstep_event_query.terraform", &eventQuery),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "name", "test-name"),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "type", "test-type"),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "source", "test-source"),
@@ -56,7 +62,7 @@ resource "lightstep_event_query" "terraform" {
 			{
 				Config: updatedEventQueryConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckEventQueryExists("lightstep_event_query.terraform", &eventQuery),
+					testAccCheckEventQueryExists(p, "lightstep_event_query.terraform", &eventQuery),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "name", "updated name"),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "type", "test-type"),
 					resource.TestCheckResourceAttr("lightstep_event_query.terraform", "source", "test-source"),
@@ -71,9 +77,10 @@ resource "lightstep_event_query" "terraform" {
 }
 
 func TestAccEventQueryImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -96,7 +103,7 @@ resource "lightstep_event_query" "imported" {
 	})
 }
 
-func testAccCheckEventQueryExists(resourceName string, attrs *client.EventQueryAttributes) resource.TestCheckFunc {
+func testAccCheckEventQueryExists(p *schema.Provider, resourceName string, attrs *client.EventQueryAttributes) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get event from TF state
 		tfEvent, ok := s.RootModule().Resources[resourceName]
@@ -109,7 +116,7 @@ func testAccCheckEventQueryExists(resourceName string, attrs *client.EventQueryA
 		}
 
 		// get event from LS
-		client := testAccProvider.Meta().(*client.Client)
+		client := p.Meta().(*providerMeta).Client
 		eq, err := client.GetEventQuery(context.Background(), testProject, tfEvent.Primary.ID)
 		if err != nil {
 			return err
@@ -120,19 +127,49 @@ func testAccCheckEventQueryExists(resourceName string, attrs *client.EventQueryA
 	}
 }
 
-func testAccEventQueryDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "lightstep_event_query" {
-			continue
-		}
+func testAccEventQueryDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "lightstep_event_query" {
+				continue
+			}
 
-		d, err := conn.GetEventQuery(context.Background(), testProject, r.Primary.ID)
-		if err == nil {
-			if d.ID == r.Primary.ID {
-				return fmt.Errorf("event query with ID (%v) still exists", r.Primary.ID)
+			d, err := conn.GetEventQuery(context.Background(), testProject, r.Primary.ID)
+			if err == nil {
+				if d.ID == r.Primary.ID {
+					return fmt.Errorf("event query with ID (%v) still exists", r.Primary.ID)
+				}
 			}
 		}
+		return nil
 	}
-	return nil
+}
+
+func TestEventQuery_fakeAPI(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_event_query"]
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_name":   "offline",
+		"name":           "deploys",
+		"type":           "test-type",
+		"source":         "test-source",
+		"query_string":   "logs",
+		"tooltip_fields": []interface{}{"foo"},
+	})
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+	require.NotEmpty(t, d.Id())
+
+	stored, ok := server.Get("offline", "event_queries", d.Id())
+	require.True(t, ok)
+	assert.Equal(t, "deploys", stored["attributes"].(map[string]interface{})["name"])
+
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+	assert.Equal(t, "logs", d.Get("query_string"))
+
+	require.False(t, r.DeleteContext(ctx, d, p.Meta()).HasError())
+	_, ok = server.Get("offline", "event_queries", d.Id())
+	assert.False(t, ok)
 }
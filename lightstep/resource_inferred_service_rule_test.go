@@ -6,9 +6,8 @@ import (
 	"math/rand"
 	"testing"
 
-	"github.com/lightstep/terraform-provider-lightstep/client"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -61,17 +60,18 @@ resource "lightstep_inferred_service_rule" "test_database_rule" {
 }	
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() { testAccPreCheck(t) },
 		// The deprecated Providers is required over ProviderFactories to initialize the provider's HTTP client for use
 		// in `testAccInferredServiceRuleDestroy` and `testAccCheckInferredServiceRuleExists`
-		Providers:    testAccProviders,
-		CheckDestroy: testAccInferredServiceRuleDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccInferredServiceRuleDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: validInitialConfiguration,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckInferredServiceRuleExists("lightstep_inferred_service_rule.test_database_rule"),
+					testAccCheckInferredServiceRuleExists(p, "lightstep_inferred_service_rule.test_database_rule"),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_database_rule", "project_name", testProject),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_database_rule", "name", databaseRuleName),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_database_rule", "description", "detects selected databases"),
@@ -86,7 +86,7 @@ resource "lightstep_inferred_service_rule" "test_database_rule" {
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_database_rule", "group_by_keys.0", "db.type"),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_database_rule", "group_by_keys.1", "db.instance"),
 
-					testAccCheckInferredServiceRuleExists("lightstep_inferred_service_rule.test_kafka_rule"),
+					testAccCheckInferredServiceRuleExists(p, "lightstep_inferred_service_rule.test_kafka_rule"),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_kafka_rule", "project_name", testProject),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_kafka_rule", "name", kafkaRuleName),
 					resource.TestCheckResourceAttr("lightstep_inferred_service_rule.test_kafka_rule", "description", ""),
@@ -149,10 +149,11 @@ resource "lightstep_inferred_service_rule" "test_import_rule_kafka" {
 }
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccInferredServiceRuleDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testAccInferredServiceRuleDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: importConfig,
@@ -173,7 +174,7 @@ resource "lightstep_inferred_service_rule" "test_import_rule_kafka" {
 	})
 }
 
-func testAccCheckInferredServiceRuleExists(resourceName string) resource.TestCheckFunc {
+func testAccCheckInferredServiceRuleExists(p *schema.Provider, resourceName string) resource.TestCheckFunc {
 	return func(tfState *terraform.State) error {
 		tfResource, ok := tfState.RootModule().Resources[resourceName]
 		if !ok {
@@ -184,7 +185,7 @@ func testAccCheckInferredServiceRuleExists(resourceName string) resource.TestChe
 			return fmt.Errorf("id is not set")
 		}
 
-		apiClient := testAccProvider.Meta().(*client.Client)
+		apiClient := p.Meta().(*providerMeta).Client
 		_, err := apiClient.GetInferredServiceRule(context.Background(), testProject, tfResource.Primary.ID)
 		if err != nil {
 			return err
@@ -194,22 +195,24 @@ func testAccCheckInferredServiceRuleExists(resourceName string) resource.TestChe
 	}
 }
 
-func testAccInferredServiceRuleDestroy(tfState *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*client.Client)
-	for _, tfResource := range tfState.RootModule().Resources {
-		if tfResource.Type != "lightstep_inferred_service_rule" {
-			continue
-		}
+func testAccInferredServiceRuleDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		apiClient := p.Meta().(*providerMeta).Client
+		for _, tfResource := range tfState.RootModule().Resources {
+			if tfResource.Type != "lightstep_inferred_service_rule" {
+				continue
+			}
 
-		apiResponse, err := apiClient.GetInferredServiceRule(context.Background(), testProject, tfResource.Primary.ID)
-		if err == nil {
-			if apiResponse.ID == tfResource.Primary.ID {
-				return fmt.Errorf("inferred service rule with ID (%v) still exists", tfResource.Primary.ID)
+			apiResponse, err := apiClient.GetInferredServiceRule(context.Background(), testProject, tfResource.Primary.ID)
+			if err == nil {
+				if apiResponse.ID == tfResource.Primary.ID {
+					return fmt.Errorf("inferred service rule with ID (%v) still exists", tfResource.Primary.ID)
+				}
 			}
-		}
 
+		}
+		return nil
 	}
-	return nil
 }
 
 func getRandomStringSuffix() string {
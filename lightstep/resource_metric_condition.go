@@ -3,13 +3,13 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -34,6 +34,7 @@ func resourceUnifiedCondition(conditionSchemaType ConditionSchemaType) *schema.R
 		Importer: &schema.ResourceImporter{
 			StateContext: p.resourceUnifiedConditionImport,
 		},
+		CustomizeDiff: customdiff.Sequence(customizeDiffLabelsAll, customizeDiffConditionPolicy),
 		Schema: map[string]*schema.Schema{
 			"project_name": {
 				Type:        schema.TypeString,
@@ -71,6 +72,7 @@ func resourceUnifiedCondition(conditionSchemaType ConditionSchemaType) *schema.R
 					},
 				},
 			},
+			"labels_all": labelsAllSchema(),
 			"custom_data": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -485,11 +487,12 @@ type resourceUnifiedConditionImp struct {
 }
 
 func (p *resourceUnifiedConditionImp) resourceUnifiedConditionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	attributes, err := getUnifiedConditionAttributesFromResource(d, p.conditionSchemaType)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get metric condition attributes from resource : %v", err))
 	}
+	attributes.Labels = mergeLabels(attributes.Labels, m.(*providerMeta).defaultLabels)
 
 	condition := client.UnifiedCondition{
 		Type:       "metric_alert",
@@ -498,7 +501,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionCreate(ctx context
 
 	created, err := c.CreateUnifiedCondition(ctx, d.Get("project_name").(string), condition)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create metric condition: %v", err))
+		return apiErrorDiagnostics(err, "failed to create metric condition", conditionPointerResolver(d, p.conditionSchemaType))
 	}
 
 	d.SetId(created.ID)
@@ -513,7 +516,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionCreate(ctx context
 	}
 	if legacy {
 		created.Attributes.Queries = attributes.Queries
-		if err := setResourceDataFromUnifiedCondition(projectName, created, d, p.conditionSchemaType); err != nil {
+		if err := setResourceDataFromUnifiedCondition(projectName, created, d, p.conditionSchemaType, m.(*providerMeta).defaultLabels); err != nil {
 			return diag.FromErr(fmt.Errorf("failed to set condition from API response to terraform state: %v", err))
 		}
 		return nil
@@ -525,7 +528,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionCreate(ctx context
 func (p *resourceUnifiedConditionImp) resourceUnifiedConditionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	prevAttrs, err := getUnifiedConditionAttributesFromResource(d, p.conditionSchemaType)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to translate resource attributes: %v", err))
@@ -534,17 +537,12 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionRead(ctx context.C
 	projectName := d.Get("project_name").(string)
 	cond, err := c.GetUnifiedCondition(ctx, projectName, d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get metric condition: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get metric condition: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get metric condition: %v", err))
 	}
 
 	legacy, err := metricConditionHasEquivalentLegacyQueries(ctx, c, projectName, prevAttrs, &cond.Attributes)
@@ -555,7 +553,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionRead(ctx context.C
 		cond.Attributes.Queries = prevAttrs.Queries
 	}
 
-	err = setResourceDataFromUnifiedCondition(projectName, *cond, d, p.conditionSchemaType)
+	err = setResourceDataFromUnifiedCondition(projectName, *cond, d, p.conditionSchemaType, m.(*providerMeta).defaultLabels)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set metric condition from API response to terraform state: %v", err))
 	}
@@ -564,14 +562,15 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionRead(ctx context.C
 }
 
 func (p *resourceUnifiedConditionImp) resourceUnifiedConditionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	attrs, err := getUnifiedConditionAttributesFromResource(d, p.conditionSchemaType)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get metric condition attributes from resource : %v", err))
 	}
+	attrs.Labels = mergeLabels(attrs.Labels, m.(*providerMeta).defaultLabels)
 
 	if _, err := c.UpdateUnifiedCondition(ctx, d.Get("project_name").(string), d.Id(), *attrs); err != nil {
-		return diag.FromErr(fmt.Errorf("failed to update metric condition: %v", err))
+		return apiErrorDiagnostics(err, "failed to update metric condition", conditionPointerResolver(d, p.conditionSchemaType))
 	}
 
 	return p.resourceUnifiedConditionRead(ctx, d, m)
@@ -580,7 +579,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionUpdate(ctx context
 func (p *resourceUnifiedConditionImp) resourceUnifiedConditionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := c.DeleteUnifiedCondition(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete metrics condition: %v", err))
 	}
@@ -592,7 +591,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionDelete(ctx context
 }
 
 func (p *resourceUnifiedConditionImp) resourceUnifiedConditionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	clnt := m.(*client.Client)
+	clnt := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
@@ -610,7 +609,7 @@ func (p *resourceUnifiedConditionImp) resourceUnifiedConditionImport(ctx context
 	}
 
 	d.SetId(id)
-	if err := setResourceDataFromUnifiedCondition(project, *c, d, p.conditionSchemaType); err != nil {
+	if err := setResourceDataFromUnifiedCondition(project, *c, d, p.conditionSchemaType, m.(*providerMeta).defaultLabels); err != nil {
 		return nil, fmt.Errorf("failed to set metric condition from API response to terraform state: %v", err)
 	}
 
@@ -1229,7 +1228,7 @@ func validateGroupBy(groupBy interface{}, queryType string) error {
 	return nil
 }
 
-func setResourceDataFromUnifiedCondition(project string, c client.UnifiedCondition, d *schema.ResourceData, schemaType ConditionSchemaType) error {
+func setResourceDataFromUnifiedCondition(project string, c client.UnifiedCondition, d *schema.ResourceData, schemaType ConditionSchemaType, defaultLabels []client.Label) error {
 	if err := d.Set("project_name", project); err != nil {
 		return fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
@@ -1242,9 +1241,8 @@ func setResourceDataFromUnifiedCondition(project string, c client.UnifiedConditi
 		return fmt.Errorf("unable to set description resource field: %v", err)
 	}
 
-	labels := extractLabels(c.Attributes.Labels)
-	if err := d.Set("label", labels); err != nil {
-		return fmt.Errorf("unable to set labels resource field: %v", err)
+	if err := setLabels(d, c.Attributes.Labels, defaultLabels); err != nil {
+		return err
 	}
 
 	if err := d.Set("custom_data", c.Attributes.CustomData); err != nil {
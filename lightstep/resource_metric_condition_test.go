@@ -292,22 +292,23 @@ resource "lightstep_metric_condition" "test" {
 }
 `
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: badCondition,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 				),
 				ExpectError: regexp.MustCompile("(Missing required argument|Insufficient metric_query blocks)"),
 			},
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "description", "A link to a playbook"),
 					resource.TestCheckResourceAttr(resourceName, "label.#", "0"),
@@ -320,7 +321,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "updated"),
 					resource.TestCheckResourceAttr(resourceName, "label.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "label.0.key", "team"),
@@ -333,7 +334,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: noDataOnlyConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "no data only"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.is_no_data", "true"),
 					resource.TestCheckResourceAttr(resourceName, "custom_data", ""),
@@ -342,7 +343,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: noDataEmptyThresholdBlockConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "no data empty thresholds"),
 					resource.TestCheckResourceAttr(resourceName, "expression.0.is_no_data", "true"),
 				),
@@ -443,15 +444,16 @@ resource "lightstep_metric_condition" "test" {
 `
 
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span latency alert"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
@@ -461,7 +463,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span latency alert - updated"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
@@ -562,15 +564,16 @@ resource "lightstep_metric_condition" "test" {
 `
 
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span rate alert"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
@@ -579,7 +582,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span rate alert - updated"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
@@ -679,15 +682,16 @@ resource "lightstep_metric_condition" "test" {
 `
 
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span error ratio alert"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
@@ -696,7 +700,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span error ratio alert - updated"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.operator_input_window_ms", "3600000"),
@@ -808,15 +812,16 @@ resource "lightstep_metric_condition" "test" {
 `
 
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Span rate alert"),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.1.query_name", "a+a"),
@@ -825,7 +830,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: updatedConditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.tql", ""),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.1.query_name", "a+a+a"),
 				),
@@ -881,15 +886,16 @@ EOT
 `, uqlQuery)
 
 	resourceName := "lightstep_alert.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: conditionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "name", "Too many requests"),
 					resource.TestCheckResourceAttr(resourceName, "query.0.query_string", uqlQuery+"\n"),
 				),
@@ -898,7 +904,7 @@ EOT
 	})
 }
 
-func testAccCheckMetricConditionExists(resourceName string, condition *client.UnifiedCondition) resource.TestCheckFunc {
+func testAccCheckMetricConditionExists(p *schema.Provider, resourceName string, condition *client.UnifiedCondition) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfCondition, ok := s.RootModule().Resources[resourceName]
 		if !ok {
@@ -909,7 +915,7 @@ func testAccCheckMetricConditionExists(resourceName string, condition *client.Un
 			return fmt.Errorf("id is not set")
 		}
 
-		providerClient := testAccProvider.Meta().(*client.Client)
+		providerClient := p.Meta().(*providerMeta).Client
 		cond, err := providerClient.GetUnifiedCondition(context.Background(), testProject, tfCondition.Primary.ID)
 		if err != nil {
 			return err
@@ -920,21 +926,23 @@ func testAccCheckMetricConditionExists(resourceName string, condition *client.Un
 	}
 }
 
-func testAccMetricConditionDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, res := range s.RootModule().Resources {
-		if res.Type != "metric_alert" {
-			continue
-		}
+func testAccMetricConditionDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, res := range s.RootModule().Resources {
+			if res.Type != "metric_alert" {
+				continue
+			}
 
-		s, err := conn.GetUnifiedCondition(context.Background(), testProject, res.Primary.ID)
-		if err == nil {
-			if s.ID == res.Primary.ID {
-				return fmt.Errorf("metric condition with ID (%v) still exists.", res.Primary.ID)
+			s, err := conn.GetUnifiedCondition(context.Background(), testProject, res.Primary.ID)
+			if err == nil {
+				if s.ID == res.Primary.ID {
+					return fmt.Errorf("metric condition with ID (%v) still exists.", res.Primary.ID)
+				}
 			}
 		}
+		return nil
 	}
-	return nil
 }
 
 func TestBuildLabelFilters(t *testing.T) {
@@ -1382,16 +1390,17 @@ resource "lightstep_metric_condition" "test" {
 `
 
 	resourceName := "lightstep_metric_condition.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			// make a valid legacy span alert
 			{
 				Config: fmt.Sprintf(conditionConfigTemplate, validQuery),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.query", "\"customer\" IN (\"test\")"),
 				),
 			},
@@ -1399,7 +1408,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: fmt.Sprintf(conditionConfigTemplate, invalidQuery),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					// the resource in state has an invalid query
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.query", invalidQuery),
 				),
@@ -1409,7 +1418,7 @@ resource "lightstep_metric_condition" "test" {
 			{
 				Config: fmt.Sprintf(conditionConfigTemplate, validQuery),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricConditionExists(resourceName, &condition),
+					testAccCheckMetricConditionExists(p, resourceName, &condition),
 					resource.TestCheckResourceAttr(resourceName, "metric_query.0.spans.0.query", "\"customer\" IN (\"test\")"),
 				),
 			},
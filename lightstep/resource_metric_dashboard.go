@@ -3,10 +3,10 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -38,6 +38,7 @@ func resourceUnifiedDashboard(chartSchemaType ChartSchemaType) *schema.Resource
 		Importer: &schema.ResourceImporter{
 			StateContext: p.resourceUnifiedDashboardImport,
 		},
+		CustomizeDiff: customdiff.Sequence(customizeDiffLabelsAll, customizeDiffDashboardPolicy),
 		Schema: map[string]*schema.Schema{
 			"project_name": {
 				Type:     schema.TypeString,
@@ -86,6 +87,7 @@ func resourceUnifiedDashboard(chartSchemaType ChartSchemaType) *schema.Resource
 					},
 				},
 			},
+			"labels_all": labelsAllSchema(),
 			"template_variable": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -367,11 +369,12 @@ type resourceUnifiedDashboardImp struct {
 }
 
 func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	attrs, hasLegacyChartsIn, err := getUnifiedDashboardAttributesFromResource(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get dashboard attributes: %v", err))
 	}
+	attrs.Labels = mergeLabels(attrs.Labels, m.(*providerMeta).defaultLabels)
 
 	dashboard := client.UnifiedDashboard{
 		Type:       "dashboard",
@@ -380,7 +383,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardCreate(ctx context
 
 	created, err := c.CreateUnifiedDashboard(ctx, d.Get("project_name").(string), dashboard)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create dashboard: %v", err))
+		return apiErrorDiagnostics(err, "failed to create dashboard", dashboardPointerResolver(d, p.chartSchemaType, hasLegacyChartsIn))
 	}
 
 	d.SetId(created.ID)
@@ -402,7 +405,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardCreate(ctx context
 				}
 			}
 		}
-		if err := p.setResourceDataFromUnifiedDashboard(projectName, dashboard, d, hasLegacyChartsIn); err != nil {
+		if err := p.setResourceDataFromUnifiedDashboard(projectName, dashboard, d, hasLegacyChartsIn, m.(*providerMeta).defaultLabels); err != nil {
 			return diag.FromErr(fmt.Errorf("failed to set dashboard from API response to terraform state: %v", err))
 		}
 		return nil
@@ -413,7 +416,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardCreate(ctx context
 
 func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	prevAttrs, hasLegacyChartsIn, err := getUnifiedDashboardAttributesFromResource(d)
 	if err != nil {
@@ -422,17 +425,12 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardRead(ctx context.C
 
 	dashboard, err := c.GetUnifiedDashboard(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get dashboard: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get dashboard: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get dashboard: %v", err))
 	}
 
 	// Support for deprecated legacy queries: if we created a new legacy query and the creation
@@ -479,7 +477,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardRead(ctx context.C
 		}
 	}
 
-	if err := p.setResourceDataFromUnifiedDashboard(d.Get("project_name").(string), *dashboard, d, hasLegacyChartsIn); err != nil {
+	if err := p.setResourceDataFromUnifiedDashboard(d.Get("project_name").(string), *dashboard, d, hasLegacyChartsIn, m.(*providerMeta).defaultLabels); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to set dashboard from API response to terraform state: %v", err))
 	}
 	return diags
@@ -753,7 +751,7 @@ func buildStringSlice(valuesIn []interface{}) []string {
 	return vals
 }
 
-func (p *resourceUnifiedDashboardImp) setResourceDataFromUnifiedDashboard(project string, dash client.UnifiedDashboard, d *schema.ResourceData, hasLegacyChartsIn bool) error {
+func (p *resourceUnifiedDashboardImp) setResourceDataFromUnifiedDashboard(project string, dash client.UnifiedDashboard, d *schema.ResourceData, hasLegacyChartsIn bool, defaultLabels []client.Label) error {
 	if err := d.Set("project_name", project); err != nil {
 		return fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
@@ -807,9 +805,8 @@ func (p *resourceUnifiedDashboardImp) setResourceDataFromUnifiedDashboard(projec
 		}
 	}
 
-	labels := extractLabels(dash.Attributes.Labels)
-	if err := d.Set("label", labels); err != nil {
-		return fmt.Errorf("unable to set labels resource field: %v", err)
+	if err := setLabels(d, dash.Attributes.Labels, defaultLabels); err != nil {
+		return err
 	}
 
 	var templateVariables []interface{}
@@ -1011,14 +1008,15 @@ func isLegacyImplicitGroup(groups []client.UnifiedGroup, hasLegacyChartsIn bool)
 }
 
 func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
-	attrs, _, err := getUnifiedDashboardAttributesFromResource(d)
+	c := m.(*providerMeta).Client
+	attrs, hasLegacyChartsIn, err := getUnifiedDashboardAttributesFromResource(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get dashboard attributes from resource : %v", err))
 	}
+	attrs.Labels = mergeLabels(attrs.Labels, m.(*providerMeta).defaultLabels)
 
 	if _, err := c.UpdateUnifiedDashboard(ctx, d.Get("project_name").(string), d.Id(), *attrs); err != nil {
-		return diag.FromErr(fmt.Errorf("failed to update dashboard: %v", err))
+		return apiErrorDiagnostics(err, "failed to update dashboard", dashboardPointerResolver(d, p.chartSchemaType, hasLegacyChartsIn))
 	}
 
 	return p.resourceUnifiedDashboardRead(ctx, d, m)
@@ -1027,7 +1025,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardUpdate(ctx context
 func (*resourceUnifiedDashboardImp) resourceUnifiedDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := c.DeleteUnifiedDashboard(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete dashboard: %v", err))
 	}
@@ -1039,7 +1037,7 @@ func (*resourceUnifiedDashboardImp) resourceUnifiedDashboardDelete(ctx context.C
 }
 
 func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
@@ -1056,7 +1054,7 @@ func (p *resourceUnifiedDashboardImp) resourceUnifiedDashboardImport(ctx context
 		return []*schema.ResourceData{}, fmt.Errorf("failed to get dashboard. err: %v", err)
 	}
 	d.SetId(id)
-	if err := p.setResourceDataFromUnifiedDashboard(project, *dash, d, false); err != nil {
+	if err := p.setResourceDataFromUnifiedDashboard(project, *dash, d, false, m.(*providerMeta).defaultLabels); err != nil {
 		return nil, fmt.Errorf("failed to set dashboard from API response to terraform state: %v", err)
 	}
 
@@ -50,16 +50,17 @@ resource "lightstep_metric_dashboard" "test" {
 
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial legacy dashboard
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.name", "hit_ratio"),
 				),
 			},
@@ -67,7 +68,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Update with no differences. Ensure the legacy format is retained.
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.name", "hit_ratio"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.rank", "1"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.type", "timeseries"),
@@ -79,7 +80,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the new metric and chart name
 				Config: updatedConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.name", "miss_ratio"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.rank", "1"),
 					resource.TestCheckResourceAttr(resourceName, "chart.0.type", "timeseries"),
@@ -146,16 +147,17 @@ resource "lightstep_metric_dashboard" "test" {
 
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testGetMetricDashboardDestroy,
+		ProviderFactories: testAccProviderFactories(p),
+		CheckDestroy:      testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial legacy dashboard
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*",
 						map[string]string{
 							"name":           "hit_ratio",
@@ -177,7 +179,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Update with no differences. Ensure the legacy format and TQL are retained.
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*",
 						map[string]string{
 							"name":           "hit_ratio",
@@ -199,7 +201,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the new metric and chart name in chart 0
 				Config: updatedConfig1,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*",
 						map[string]string{
 							"name":        "miss_ratio",
@@ -218,7 +220,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will the TQL query of chart 1
 				Config: updatedConfig2,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*",
 						map[string]string{
 							"name":        "miss_ratio",
@@ -371,16 +373,17 @@ resource "lightstep_metric_dashboard" "test" {
 
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial legacy dashboard
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*", map[string]string{
 						"name":        "CPU: Capped Target",
 						"description": "VPA will adjust pods' CPU requests until the average across all replicas is less than or equal to this value",
@@ -391,7 +394,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Update with no differences. Ensure the legacy format is retained.
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "chart.*", map[string]string{
 						"name":        "CPU: Capped Target",
 						"description": "VPA will adjust pods' CPU requests until the average across all replicas is less than or equal to this value",
@@ -436,16 +439,17 @@ resource "lightstep_metric_dashboard" "test" {
 
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial dashboard with a service health panel. verify the name and panel_options.percentile
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.service_health_panel.0.name", "test_service_health_panel"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.service_health_panel.0.panel_options.0.sort_by", "latency"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.service_health_panel.0.panel_options.0.sort_direction", "asc"),
@@ -455,7 +459,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the new metric and chart name
 				Config: updatedConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.service_health_panel.0.name", "test_service_health_panel"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.service_health_panel.0.panel_options.0.sort_direction", "desc"),
 				),
@@ -546,16 +550,17 @@ resource "lightstep_metric_dashboard" "test" {
 `
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial dashboard with a chart and make sure it has thresholds
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.name", "cpu"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.threshold.#", "2"),
 
@@ -576,7 +581,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the a label for the second threshold
 				Config: updatedDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.name", "cpu"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.chart.0.threshold.#", "2"),
 
@@ -627,16 +632,17 @@ resource "lightstep_metric_dashboard" "test" {
 }
 `
 	resourceName := "lightstep_metric_dashboard.test"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial dashboard with a list of event_query_ids
 				Config: dashboardConfig + "\n" + eventQueryConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckEventQueryExists("lightstep_event_query.terraform", &eventQuery),
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckEventQueryExists(p, "lightstep_event_query.terraform", &eventQuery),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "event_query_ids.#", "1"),
 				),
 			},
@@ -644,7 +650,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the new metric and chart name
 				Config: updatedDashboardConfig + "\n" + eventQueryConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "event_query_ids.#", "0"),
 				),
 			},
@@ -707,16 +713,17 @@ resource "lightstep_metric_dashboard" "test" {
 
 	resourceName := "lightstep_metric_dashboard.test"
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testGetMetricDashboardDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testGetMetricDashboardDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				// Create the initial dashboard with a service health panel. verify the name and panel_options.percentile
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.name", "test_alerts_list_panel"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.panel_options.0.sort_by", "status"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.panel_options.0.sort_direction", "asc"),
@@ -734,7 +741,7 @@ resource "lightstep_metric_dashboard" "test" {
 				// Updated config will contain the new metric and chart name
 				Config: updatedConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.name", "test_alerts_list_panel"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.panel_options.0.sort_direction", "desc"),
 					resource.TestCheckResourceAttr(resourceName, "group.0.alerts_list_panel.0.filter_by.0.predicate.0.label.0.value", "pepsi"),
@@ -936,14 +943,15 @@ resource "lightstep_dashboard" "test_workflow_links" {
 }
 `
 	resourceName := "lightstep_dashboard.test_workflow_links"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: dashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.0.name", "test dashboard link"),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.0.url", "https://test.com"),
@@ -958,7 +966,7 @@ resource "lightstep_dashboard" "test_workflow_links" {
 			{
 				Config: updatedDashboardConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMetricDashboardExists(resourceName, &dashboard),
+					testAccCheckMetricDashboardExists(p, resourceName, &dashboard),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.#", "2"),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.0.name", "test dashboard link"),
 					resource.TestCheckResourceAttr(resourceName, "workflow_link.0.url", "https://test.com"),
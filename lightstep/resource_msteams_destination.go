@@ -0,0 +1,105 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMSTeamsDestination() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMSTeamsDestinationCreate,
+		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceMSTeamsDestinationUpdate,
+		DeleteContext: resourceDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMSTeamsDestinationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Lightstep project name",
+			},
+			"destination_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Lightstep destination name",
+			},
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"https"}),
+				Description:  "Incoming webhook URL of the Microsoft Teams channel. Anyone with the URL can post to the channel, so it's sensitive",
+			},
+			"template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Adaptive card template of the message. Lightstep's default card is sent when unset",
+			},
+		},
+	}
+}
+
+func resourceMSTeamsDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	attrs := buildMSTeamsAttributes(d)
+	dest := client.Destination{
+		Type:       "destination",
+		Attributes: attrs,
+	}
+
+	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string), dest)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Microsoft Teams destination %v: %v", attrs.Name, err))
+	}
+
+	d.SetId(destination.ID)
+	return resourceDestinationRead(ctx, d, m)
+}
+
+func resourceMSTeamsDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return updateDestination(ctx, d, m, buildMSTeamsAttributes(d))
+}
+
+func buildMSTeamsAttributes(d *schema.ResourceData) client.MSTeamsAttributes {
+	return client.MSTeamsAttributes{
+		Name:            d.Get("destination_name").(string),
+		DestinationType: "msteams",
+		URL:             d.Get("url").(string),
+		Template:        d.Get("template").(string),
+	}
+}
+
+func resourceMSTeamsDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*providerMeta).Client
+
+	ids, err := splitID("lightstep_msteams_destination", d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	project, id := ids[0], ids[1]
+	dest, err := c.GetDestination(ctx, project, id)
+	if err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("failed to get Microsoft Teams destination: %v", err)
+	}
+
+	d.SetId(dest.ID)
+	if err := d.Set("project_name", project); err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
+	}
+
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
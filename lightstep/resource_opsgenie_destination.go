@@ -0,0 +1,208 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var opsgeniePriorities = []string{"P1", "P2", "P3", "P4", "P5"}
+
+func resourceOpsgenieDestination() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOpsgenieDestinationCreate,
+		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceOpsgenieDestinationUpdate,
+		DeleteContext: resourceDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpsgenieDestinationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Lightstep project name",
+			},
+			"destination_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Lightstep destination name",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Key of an Opsgenie API integration. To create one follow the docs here - https://support.atlassian.com/opsgenie/docs/create-a-default-api-integration/",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "us",
+				ValidateFunc: validation.StringInSlice([]string{"us", "eu"}, false),
+				Description:  "Region of the Opsgenie instance: `us` or `eu`",
+			},
+			"responder": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Teams, users, escalations or schedules notified of alerts",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"team", "user", "escalation", "schedule"}, false),
+							Description:  "One of `team`, `user`, `escalation` or `schedule`",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the team, escalation or schedule, or username of the user",
+						},
+					},
+				},
+			},
+			"priority_mapping": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Opsgenie priorities of Lightstep alerts. Opsgenie's default priority is used when unset",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"warning": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "P3",
+							ValidateFunc: validation.StringInSlice(opsgeniePriorities, false),
+							Description:  "Priority of alerts in a warning state, from `P1` to `P5`",
+						},
+						"critical": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "P1",
+							ValidateFunc: validation.StringInSlice(opsgeniePriorities, false),
+							Description:  "Priority of alerts in a critical state, from `P1` to `P5`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceOpsgenieDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string),
+		client.Destination{
+			Type:       "destination",
+			Attributes: buildOpsgenieAttributes(d),
+		})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create opsgenie destination: %v", err))
+	}
+
+	d.SetId(destination.ID)
+	return resourceDestinationRead(ctx, d, m)
+}
+
+func resourceOpsgenieDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return updateDestination(ctx, d, m, buildOpsgenieAttributes(d))
+}
+
+func buildOpsgenieAttributes(d *schema.ResourceData) client.OpsgenieAttributes {
+	attrs := client.OpsgenieAttributes{
+		Name:            d.Get("destination_name").(string),
+		DestinationType: "opsgenie",
+		APIKey:          d.Get("api_key").(string),
+		Region:          d.Get("region").(string),
+	}
+
+	for _, r := range d.Get("responder").(*schema.Set).List() {
+		responder := r.(map[string]interface{})
+		attrs.Responders = append(attrs.Responders, client.OpsgenieResponder{
+			Type: responder["type"].(string),
+			Name: responder["name"].(string),
+		})
+	}
+
+	if mapping := d.Get("priority_mapping").([]interface{}); len(mapping) > 0 && mapping[0] != nil {
+		priorities := mapping[0].(map[string]interface{})
+		attrs.PriorityMapping = &client.OpsgeniePriorityMapping{
+			Warning:  priorities["warning"].(string),
+			Critical: priorities["critical"].(string),
+		}
+	}
+	return attrs
+}
+
+func setOpsgenieAttributes(d *schema.ResourceData, attrs client.OpsgenieAttributes) error {
+	if err := d.Set("destination_name", attrs.Name); err != nil {
+		return fmt.Errorf("unable to set destination_name resource field: %v", err)
+	}
+	// keep the key from the configuration when the API redacts it
+	if attrs.APIKey != "" {
+		if err := d.Set("api_key", attrs.APIKey); err != nil {
+			return fmt.Errorf("unable to set api_key resource field: %v", err)
+		}
+	}
+	// an empty region means the API left it out, not that it differs from the default
+	if attrs.Region != "" {
+		if err := d.Set("region", attrs.Region); err != nil {
+			return fmt.Errorf("unable to set region resource field: %v", err)
+		}
+	}
+
+	var responders []interface{}
+	for _, r := range attrs.Responders {
+		responders = append(responders, map[string]interface{}{
+			"type": r.Type,
+			"name": r.Name,
+		})
+	}
+	if err := d.Set("responder", responders); err != nil {
+		return fmt.Errorf("unable to set responder resource field: %v", err)
+	}
+
+	var mapping []interface{}
+	if attrs.PriorityMapping != nil {
+		mapping = []interface{}{map[string]interface{}{
+			"warning":  attrs.PriorityMapping.Warning,
+			"critical": attrs.PriorityMapping.Critical,
+		}}
+	}
+	if err := d.Set("priority_mapping", mapping); err != nil {
+		return fmt.Errorf("unable to set priority_mapping resource field: %v", err)
+	}
+	return nil
+}
+
+func resourceOpsgenieDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*providerMeta).Client
+
+	ids, err := splitID("lightstep_opsgenie_destination", d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	project, id := ids[0], ids[1]
+	dest, err := c.GetDestination(ctx, project, id)
+	if err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("failed to get opsgenie destination: %v", err)
+	}
+
+	d.SetId(dest.ID)
+	if err := d.Set("project_name", project); err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
+	}
+
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,33 @@
+package lightstep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsgenieDestination_regionLeftOut(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_opsgenie_destination"]
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "on-call",
+		"api_key":          "opsgenie-key",
+		"region":           "eu",
+	})
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	// a response without a region doesn't reset it
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	delete(stored["attributes"].(map[string]interface{}), "region")
+	server.Put("offline", "destinations", stored)
+
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+	assert.Equal(t, "eu", d.Get("region"))
+}
@@ -14,6 +14,7 @@ func resourcePagerdutyDestination() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePagerdutyDestinationCreate,
 		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourcePagerdutyDestinationUpdate,
 		DeleteContext: resourceDestinationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePagerdutyDestinationImport,
@@ -28,13 +29,12 @@ func resourcePagerdutyDestination() *schema.Resource {
 			"destination_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Lightstep destination name",
 			},
 			"integration_key": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+				Sensitive:   true,
 				Description: "PagerDuty Service Integration Key. To create one follow the docs here - https://support.pagerduty.com/docs/services-and-integrations#add-integrations-to-an-existing-service",
 			},
 		},
@@ -42,15 +42,11 @@ func resourcePagerdutyDestination() *schema.Resource {
 }
 
 func resourcePagerdutyDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string),
 		client.Destination{
-			Type: "destination",
-			Attributes: client.PagerdutyAttributes{
-				Name:            d.Get("destination_name").(string),
-				IntegrationKey:  d.Get("integration_key").(string),
-				DestinationType: "pagerduty",
-			},
+			Type:       "destination",
+			Attributes: buildPagerdutyAttributes(d),
 		})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to create pagerduty destination: %v", err))
@@ -60,10 +56,22 @@ func resourcePagerdutyDestinationCreate(ctx context.Context, d *schema.ResourceD
 	return resourceDestinationRead(ctx, d, m)
 }
 
+func resourcePagerdutyDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return updateDestination(ctx, d, m, buildPagerdutyAttributes(d))
+}
+
+func buildPagerdutyAttributes(d *schema.ResourceData) client.PagerdutyAttributes {
+	return client.PagerdutyAttributes{
+		Name:            d.Get("destination_name").(string),
+		IntegrationKey:  d.Get("integration_key").(string),
+		DestinationType: "pagerduty",
+	}
+}
+
 func resourcePagerdutyDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
-	ids, err := splitID(d.Id())
+	ids, err := splitID("lightstep_pagerduty_destination", d.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +87,8 @@ func resourcePagerdutyDestinationImport(ctx context.Context, d *schema.ResourceD
 		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
 
-	attributes := dest.Attributes.(map[string]interface{})
-	if err := d.Set("destination_name", attributes["name"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set destination_name resource field: %v", err)
-	}
-
-	if err := d.Set("integration_key", attributes["integration_key"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set integration_key resource field: %v", err)
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
 	}
 
 	return []*schema.ResourceData{d}, nil
@@ -9,7 +9,10 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccPagerdutyDestination(t *testing.T) {
@@ -29,22 +32,23 @@ resource "lightstep_pagerduty_destination" "pagerduty" {
   integration_key = "abc123def456"
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccPagerdutyDestinationDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccPagerdutyDestinationDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: missingExpressionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPagerdutyDestinationExists("lightstep_pagerduty_destination.missing_pagerduty", &destination),
+					testAccCheckPagerdutyDestinationExists(p, "lightstep_pagerduty_destination.missing_pagerduty", &destination),
 				),
 				ExpectError: regexp.MustCompile("The argument \"integration_key\" is required, but no definition was found."),
 			},
 			{
 				Config: destinationConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPagerdutyDestinationExists("lightstep_pagerduty_destination.pagerduty", &destination),
+					testAccCheckPagerdutyDestinationExists(p, "lightstep_pagerduty_destination.pagerduty", &destination),
 					resource.TestCheckResourceAttr("lightstep_pagerduty_destination.pagerduty", "destination_name", "Acceptance Test Destination"),
 					resource.TestCheckResourceAttr("lightstep_pagerduty_destination.pagerduty", "integration_key", "abc123def456"),
 				),
@@ -55,9 +59,10 @@ resource "lightstep_pagerduty_destination" "pagerduty" {
 }
 
 func TestAccPagerdutyDestinationImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -78,7 +83,7 @@ resource "lightstep_pagerduty_destination" "pagerduty" {
 	})
 }
 
-func testAccCheckPagerdutyDestinationExists(resourceName string, destination *client.Destination) resource.TestCheckFunc {
+func testAccCheckPagerdutyDestinationExists(p *schema.Provider, resourceName string, destination *client.Destination) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get destination from TF state
 		tfDestination, ok := s.RootModule().Resources[resourceName]
@@ -91,7 +96,7 @@ func testAccCheckPagerdutyDestinationExists(resourceName string, destination *cl
 		}
 
 		// get destination from LS
-		c := testAccProvider.Meta().(*client.Client)
+		c := p.Meta().(*providerMeta).Client
 		d, err := c.GetDestination(context.Background(), testProject, tfDestination.Primary.ID)
 		if err != nil {
 			return err
@@ -102,20 +107,50 @@ func testAccCheckPagerdutyDestinationExists(resourceName string, destination *cl
 	}
 }
 
-func testAccPagerdutyDestinationDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, resource := range s.RootModule().Resources {
-		if resource.Type != "lightstep_pagerduty_destination" {
-			continue
-		}
+func testAccPagerdutyDestinationDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, resource := range s.RootModule().Resources {
+			if resource.Type != "lightstep_pagerduty_destination" {
+				continue
+			}
 
-		s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
-		if err == nil {
-			if s.ID == resource.Primary.ID {
-				return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+			s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
+			if err == nil {
+				if s.ID == resource.Primary.ID {
+					return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+				}
 			}
+
 		}
+		return nil
+	}
+}
+
+func TestPagerdutyDestination_fakeAPI_drift(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_pagerduty_destination"]
+	ctx := context.Background()
 
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "pd",
+		"integration_key":  "abc",
 	}
-	return nil
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	stored["attributes"].(map[string]interface{})["name"] = "renamed"
+	server.Put("offline", "destinations", stored)
+
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+	assert.Equal(t, "renamed", d.Get("destination_name"))
+	assert.Equal(t, "abc", d.Get("integration_key"))
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "destination_name")
 }
@@ -85,7 +85,7 @@ func resourceSAMLGroupMappings() *schema.Resource {
 }
 
 func resourceSAMLGroupMappingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	samlGroupMappings, err := c.ListSAMLGroupMappings(ctx)
 	if err != nil {
@@ -97,7 +97,7 @@ func resourceSAMLGroupMappingsRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSAMLGroupMappingsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	// Read the proposed plan.
 	mappings, err := getSAMLGroupMappingsResource(d)
@@ -143,7 +143,7 @@ func resourceSAMLGroupMappingsCreateOrUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceSAMLGroupMappingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	// Update SAML Group Mappings with no mappings.
 	err := c.UpdateSAMLGroupMappings(ctx, client.SAMLGroupMappings{})
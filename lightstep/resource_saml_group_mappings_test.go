@@ -102,9 +102,10 @@ resource "lightstep_saml_group_mappings" "group_mappings" {
 }
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: validMappings,
@@ -3,8 +3,8 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,6 +16,7 @@ func resourceServiceNowDestination() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceServiceNowDestinationCreate,
 		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceServiceNowDestinationUpdate,
 		DeleteContext: resourceDestinationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceNowDestinationImport,
@@ -29,13 +30,11 @@ func resourceServiceNowDestination() *schema.Resource {
 			"destination_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Name of the ServiceNow destination",
 			},
 			"url": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				Description:  "ServiceNow instance URL",
 				ValidateFunc: validation.IsURLWithScheme([]string{"https"}),
 			},
@@ -44,20 +43,35 @@ func resourceServiceNowDestination() *schema.Resource {
 				MinItems:    1,
 				MaxItems:    1,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Basic auth used to authenticate with the ServiceNow instance",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"username": {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						"password": {
-							Type:      schema.TypeString,
-							Sensitive: true,
-							Required:  true,
-							ForceNew:  true,
+							Type:         schema.TypeString,
+							Sensitive:    true,
+							Optional:     true,
+							ExactlyOneOf: []string{"auth.0.password", "auth.0.password_wo"},
+							// the API never returns the password, so an imported destination
+							// has none in its state until it's changed
+							DiffSuppressFunc: func(_, old, _ string, d *schema.ResourceData) bool {
+								return old == "" && d.Id() != ""
+							},
+						},
+						"password_wo": {
+							Type:        schema.TypeString,
+							Sensitive:   true,
+							Optional:    true,
+							WriteOnly:   true,
+							Description: "Password sent to Lightstep without being stored in the state. Requires Terraform 1.11 or later",
+						},
+						"password_wo_version": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Change to send a new `password_wo` to Lightstep. Write-only values aren't stored, so changing `password_wo` alone doesn't update the destination",
 						},
 					},
 				},
@@ -67,16 +81,10 @@ func resourceServiceNowDestination() *schema.Resource {
 }
 
 func resourceServiceNowDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
-	attrs := client.ServiceNowAttributes{
-		Name:            d.Get("destination_name").(string),
-		DestinationType: "servicenow",
-		URL:             d.Get("url").(string),
-	}
-	auth := d.Get("auth").([]interface{})[0].(map[string]interface{})
-	attrs.Auth = client.Auth{
-		Username: auth["username"].(string),
-		Password: auth["password"].(string),
+	c := m.(*providerMeta).Client
+	attrs, diags := buildServiceNowAttributes(d)
+	if diags.HasError() {
+		return diags
 	}
 	dest := client.Destination{
 		Type:       "destination",
@@ -92,12 +100,43 @@ func resourceServiceNowDestinationCreate(ctx context.Context, d *schema.Resource
 	return resourceDestinationRead(ctx, d, m)
 }
 
+func resourceServiceNowDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	attrs, diags := buildServiceNowAttributes(d)
+	if diags.HasError() {
+		return diags
+	}
+	return updateDestination(ctx, d, m, attrs)
+}
+
+func buildServiceNowAttributes(d *schema.ResourceData) (client.ServiceNowAttributes, diag.Diagnostics) {
+	auth := d.Get("auth").([]interface{})[0].(map[string]interface{})
+	attrs := client.ServiceNowAttributes{
+		Name:            d.Get("destination_name").(string),
+		DestinationType: "servicenow",
+		URL:             d.Get("url").(string),
+		Auth: client.Auth{
+			Username: auth["username"].(string),
+			// empty for imported destinations, leaving the password unchanged
+			Password: auth["password"].(string),
+		},
+	}
+
+	if attrs.Auth.Password == "" {
+		password, diags := writeOnlyString(d, cty.GetAttrPath("auth").IndexInt(0).GetAttr("password_wo"))
+		if diags.HasError() {
+			return attrs, diags
+		}
+		attrs.Auth.Password = password
+	}
+	return attrs, nil
+}
+
 func resourceServiceNowDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
-	ids := strings.Split(d.Id(), ".")
-	if len(ids) != 2 {
-		return []*schema.ResourceData{}, fmt.Errorf("error importing lightstep_servicenow_destination. Expecting an  ID formed as '<lightstep_project>.<lightstep_destination_ID>'")
+	ids, err := splitID("lightstep_servicenow_destination", d.Id())
+	if err != nil {
+		return nil, err
 	}
 
 	project, id := ids[0], ids[1]
@@ -111,17 +150,8 @@ func resourceServiceNowDestinationImport(ctx context.Context, d *schema.Resource
 		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
 
-	attributes := dest.Attributes.(map[string]interface{})
-	if err := d.Set("destination_name", attributes["name"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set destination_name resource field: %v", err)
-	}
-
-	if err := d.Set("url", attributes["url"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set url resource field: %v", err)
-	}
-
-	if err := d.Set("auth", []interface{}{attributes["auth"]}); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set auth resource field: %v", err)
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
 	}
 
 	return []*schema.ResourceData{d}, nil
@@ -9,7 +9,10 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccServiceNowDestination(t *testing.T) {
@@ -44,29 +47,30 @@ resource "lightstep_servicenow_destination" "servicenow" {
   }
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccServiceNowDestinationDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccServiceNowDestinationDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: missingUrlConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServiceNowDestinationExists("lightstep_servicenow_destination.missing_url", &destination),
+					testAccCheckServiceNowDestinationExists(p, "lightstep_servicenow_destination.missing_url", &destination),
 				),
 				ExpectError: regexp.MustCompile("The argument \"url\" is required, but no definition was found."),
 			},
 			{
 				Config: missingAuthConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServiceNowDestinationExists("lightstep_servicenow_destination.missing_auth", &destination),
+					testAccCheckServiceNowDestinationExists(p, "lightstep_servicenow_destination.missing_auth", &destination),
 				),
 				ExpectError: regexp.MustCompile("Insufficient auth blocks"),
 			},
 			{
 				Config: destinationConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckWebhookDestinationExists("lightstep_servicenow_destination.servicenow", &destination),
+					testAccCheckWebhookDestinationExists(p, "lightstep_servicenow_destination.servicenow", &destination),
 					resource.TestCheckResourceAttr("lightstep_servicenow_destination.servicenow", "destination_name", "my-destination"),
 					resource.TestCheckResourceAttr("lightstep_servicenow_destination.servicenow", "url", "https://example.com"),
 					resource.TestCheckResourceAttr("lightstep_servicenow_destination.servicenow", "auth.0.username", "me"),
@@ -79,9 +83,10 @@ resource "lightstep_servicenow_destination" "servicenow" {
 }
 
 func TestAccServiceNowDestinationImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -107,7 +112,7 @@ resource "lightstep_servicenow_destination" "servicenow" {
 	})
 }
 
-func testAccCheckServiceNowDestinationExists(resourceName string, destination *client.Destination) resource.TestCheckFunc {
+func testAccCheckServiceNowDestinationExists(p *schema.Provider, resourceName string, destination *client.Destination) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get destination from TF state
 		tfDestination, ok := s.RootModule().Resources[resourceName]
@@ -120,7 +125,7 @@ func testAccCheckServiceNowDestinationExists(resourceName string, destination *c
 		}
 
 		// get destination from LS
-		client := testAccProvider.Meta().(*client.Client)
+		client := p.Meta().(*providerMeta).Client
 		d, err := client.GetDestination(context.Background(), testProject, tfDestination.Primary.ID)
 		if err != nil {
 			return err
@@ -131,20 +136,154 @@ func testAccCheckServiceNowDestinationExists(resourceName string, destination *c
 	}
 }
 
-func testAccServiceNowDestinationDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, resource := range s.RootModule().Resources {
-		if resource.Type != "lightstep_servicenow_destination" {
-			continue
-		}
+func testAccServiceNowDestinationDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, resource := range s.RootModule().Resources {
+			if resource.Type != "lightstep_servicenow_destination" {
+				continue
+			}
 
-		s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
-		if err == nil {
-			if s.ID == resource.Primary.ID {
-				return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+			s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
+			if err == nil {
+				if s.ID == resource.Primary.ID {
+					return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+				}
 			}
+
 		}
+		return nil
+	}
+}
 
+func TestServiceNowDestination_fakeAPI_password(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_servicenow_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "sn",
+		"url":              "https://example.service-now.com",
+		"auth": []interface{}{map[string]interface{}{
+			"username": "admin",
+			"password": "secret",
+		}},
 	}
-	return nil
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	// the API doesn't return the password, which must not show up as a diff
+	assert.Equal(t, "secret", d.Get("auth.0.password"))
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// nor after importing the destination
+	imported := r.Data(nil)
+	imported.SetId("offline." + d.Id())
+	states, err := r.Importer.StateContext(ctx, imported, p.Meta())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "admin", states[0].Get("auth.0.username"))
+	diff, err = r.Diff(ctx, states[0].State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// while a username changed outside of Terraform does
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	stored["attributes"].(map[string]interface{})["auth"].(map[string]interface{})["username"] = "root"
+	server.Put("offline", "destinations", stored)
+
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+	assert.Equal(t, "root", d.Get("auth.0.username"))
+	assert.Equal(t, "secret", d.Get("auth.0.password"))
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "auth.0.username")
+}
+
+func TestServiceNowDestination_fakeAPI_update(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_servicenow_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "sn",
+		"url":              "https://example.service-now.com",
+		"auth": []interface{}{map[string]interface{}{
+			"username": "admin",
+			"password": "secret",
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	config["auth"] = []interface{}{map[string]interface{}{
+		"username": "admin",
+		"password": "rotated",
+	}}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+
+	state, diags := r.Apply(ctx, d.State(), diff, p.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, d.Id(), state.ID)
+	assert.Equal(t, "rotated", state.Attributes["auth.0.password"])
+
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	assert.Equal(t, "rotated", stored["attributes"].(map[string]interface{})["auth"].(map[string]interface{})["password"])
+}
+
+func TestServiceNowDestination_fakeAPI_writeOnlyPassword(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_servicenow_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "sn",
+		"url":              "https://example.service-now.com",
+		"auth": []interface{}{map[string]interface{}{
+			"username":            "admin",
+			"password_wo_version": 1,
+		}},
+	}
+	d := testResourceDataWithWriteOnly(t, r, config, map[string]interface{}{
+		"auth": []interface{}{map[string]interface{}{
+			"username":            "admin",
+			"password_wo":         "secret",
+			"password_wo_version": 1,
+		}},
+	})
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	assert.Equal(t, "secret", stored["attributes"].(map[string]interface{})["auth"].(map[string]interface{})["password"])
+	assert.Empty(t, d.Get("auth.0.password"))
+	assert.Equal(t, 1, d.Get("auth.0.password_wo_version"))
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// password and password_wo are mutually exclusive
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "sn",
+		"url":              "https://example.service-now.com",
+		"auth": []interface{}{map[string]interface{}{
+			"username":    "admin",
+			"password":    "secret",
+			"password_wo": "secret",
+		}},
+	}))
+	assert.True(t, diags.HasError())
 }
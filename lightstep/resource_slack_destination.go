@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
@@ -15,6 +14,7 @@ func resourceSlackDestination() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSlackDestinationCreate,
 		ReadContext:   resourceSlackDestinationRead,
+		UpdateContext: resourceSlackDestinationUpdate,
 		DeleteContext: resourceDestinationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSlackDestinationImport,
@@ -29,7 +29,6 @@ func resourceSlackDestination() *schema.Resource {
 			"channel": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "One of: slack channel name (#channel), channel ID, handle name (@user).",
 			},
 		},
@@ -39,35 +38,27 @@ func resourceSlackDestination() *schema.Resource {
 func resourceSlackDestinationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	dest, err := c.GetDestination(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get slack destination: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get slack destination: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get slack destination: %v", err))
 	}
 
-	if err := d.Set("channel", dest.Attributes.(map[string]interface{})["channel"]); err != nil {
-		return diag.FromErr(fmt.Errorf("unable to set channel resource field: %v", err))
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return diags
 }
 
 func resourceSlackDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
-	attrs := client.SlackAttributes{
-		Channel:         d.Get("channel").(string),
-		DestinationType: "slack",
-	}
+	c := m.(*providerMeta).Client
+	attrs := buildSlackAttributes(d)
 	dest := client.Destination{
 		Type:       "destination",
 		Attributes: attrs,
@@ -82,10 +73,21 @@ func resourceSlackDestinationCreate(ctx context.Context, d *schema.ResourceData,
 	return resourceDestinationRead(ctx, d, m)
 }
 
+func resourceSlackDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return updateDestination(ctx, d, m, buildSlackAttributes(d))
+}
+
+func buildSlackAttributes(d *schema.ResourceData) client.SlackAttributes {
+	return client.SlackAttributes{
+		Channel:         d.Get("channel").(string),
+		DestinationType: "slack",
+	}
+}
+
 func resourceSlackDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
-	ids, err := splitID(d.Id())
+	ids, err := splitID("lightstep_slack_destination", d.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -101,9 +103,8 @@ func resourceSlackDestinationImport(ctx context.Context, d *schema.ResourceData,
 		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
 
-	attributes := dest.Attributes.(map[string]interface{})
-	if err := d.Set("channel", attributes["channel"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set channel resource field: %v", err)
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
 	}
 
 	return []*schema.ResourceData{d}, nil
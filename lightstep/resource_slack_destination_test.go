@@ -8,6 +8,7 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -20,15 +21,16 @@ resource "lightstep_slack_destination" "slack" {
   channel = "#emergency-room"
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccSlackDestinationDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccSlackDestinationDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: destinationConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSlackDestinationExists("lightstep_slack_destination.slack", &destination),
+					testAccCheckSlackDestinationExists(p, "lightstep_slack_destination.slack", &destination),
 					resource.TestCheckResourceAttr("lightstep_slack_destination.slack", "channel", "#emergency-room"),
 				),
 			},
@@ -38,9 +40,10 @@ resource "lightstep_slack_destination" "slack" {
 }
 
 func TestAccSlackDestinationImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -60,7 +63,7 @@ resource "lightstep_slack_destination" "imported" {
 	})
 }
 
-func testAccCheckSlackDestinationExists(resourceName string, destination *client.Destination) resource.TestCheckFunc {
+func testAccCheckSlackDestinationExists(p *schema.Provider, resourceName string, destination *client.Destination) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get destination from TF state
 		tfDestination, ok := s.RootModule().Resources[resourceName]
@@ -73,7 +76,7 @@ func testAccCheckSlackDestinationExists(resourceName string, destination *client
 		}
 
 		// get destination from LS
-		client := testAccProvider.Meta().(*client.Client)
+		client := p.Meta().(*providerMeta).Client
 		d, err := client.GetDestination(context.Background(), testProject, tfDestination.Primary.ID)
 		if err != nil {
 			return err
@@ -84,19 +87,21 @@ func testAccCheckSlackDestinationExists(resourceName string, destination *client
 	}
 }
 
-func testAccSlackDestinationDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, r := range s.RootModule().Resources {
-		if r.Type != "lightstep_slack_destination" {
-			continue
-		}
+func testAccSlackDestinationDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, r := range s.RootModule().Resources {
+			if r.Type != "lightstep_slack_destination" {
+				continue
+			}
 
-		d, err := conn.GetDestination(context.Background(), testProject, r.Primary.ID)
-		if err == nil {
-			if d.ID == r.Primary.ID {
-				return fmt.Errorf("destination with ID (%v) still exists.", r.Primary.ID)
+			d, err := conn.GetDestination(context.Background(), testProject, r.Primary.ID)
+			if err == nil {
+				if d.ID == r.Primary.ID {
+					return fmt.Errorf("destination with ID (%v) still exists.", r.Primary.ID)
+				}
 			}
 		}
+		return nil
 	}
-	return nil
 }
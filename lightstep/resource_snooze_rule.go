@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -237,7 +236,7 @@ type resourceSnoozeRuleImp struct {
 }
 
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	rule, err := getSnoozeRuleFromResource(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get snooze rule from resource : %v", err))
@@ -256,21 +255,16 @@ func (p *resourceSnoozeRuleImp) resourceSnoozeRuleCreate(ctx context.Context, d
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	projectName := d.Get("project_name").(string)
 	rule, err := c.GetSnoozeRule(ctx, projectName, d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get snooze rule: %v", err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get snooze rule: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get snooze rule: %v", err))
 	}
 
 	err = setResourceDataFromSnoozeRule(projectName, *rule, d)
@@ -282,7 +276,7 @@ func (p *resourceSnoozeRuleImp) resourceSnoozeRuleRead(ctx context.Context, d *s
 }
 
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	rule, err := getSnoozeRuleFromResource(d)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to get snooze rule from resource : %v", err))
@@ -296,7 +290,7 @@ func (p *resourceSnoozeRuleImp) resourceSnoozeRuleUpdate(ctx context.Context, d
 }
 
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	ruleToValidate, err := getSnoozeRuleFromResource(d)
 	if err != nil {
 		return fmt.Errorf("failed to get snooze rule from resource : %v", err)
@@ -314,7 +308,7 @@ func (p *resourceSnoozeRuleImp) resourceSnoozeRuleCustomizeDiff(ctx context.Cont
 }
 
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
@@ -339,7 +333,7 @@ func (p *resourceSnoozeRuleImp) resourceSnoozeRuleImport(ctx context.Context, d
 func (p *resourceSnoozeRuleImp) resourceSnoozeRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := c.DeleteSnoozeRule(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete snooze rule: %v", err))
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -80,15 +81,16 @@ resource "lightstep_snooze_rule" "snooze1" {
 `
 
 	resourceName := "lightstep_snooze_rule.snooze1"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: ruleConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSnoozeRuleExists(resourceName, &rule),
+					testAccCheckSnoozeRuleExists(p, resourceName, &rule),
 					resource.TestCheckResourceAttr(resourceName, "title", "Snooze Test"),
 					resource.TestCheckResourceAttr(resourceName, "scope.0.basic.0.scope_filter.0.alert_ids.0", "alert1"),
 					resource.TestCheckResourceAttr(resourceName, "scope.0.basic.0.scope_filter.0.alert_ids.1", "alert2"),
@@ -103,7 +105,7 @@ resource "lightstep_snooze_rule" "snooze1" {
 			{
 				Config: updatedRuleConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSnoozeRuleExists(resourceName, &rule),
+					testAccCheckSnoozeRuleExists(p, resourceName, &rule),
 					resource.TestCheckResourceAttr(resourceName, "title", "Snooze Test Updated"),
 					resource.TestCheckResourceAttr(resourceName, "scope.0.basic.0.scope_filter.1.label_predicate.0.label.0.value", "changed"),
 				),
@@ -146,15 +148,16 @@ resource "lightstep_snooze_rule" "snooze1" {
 }
 `
 	resourceName := "lightstep_snooze_rule.snooze1"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: ruleConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSnoozeRuleExists(resourceName, &rule),
+					testAccCheckSnoozeRuleExists(p, resourceName, &rule),
 					resource.TestCheckResourceAttr(resourceName, "title", "Snooze Test"),
 					resource.TestCheckResourceAttr(resourceName, "scope.0.basic.0.scope_filter.0.alert_ids.0", "alert1"),
 					resource.TestCheckResourceAttr(resourceName, "schedule.0.recurring.0.timezone", "America/Los_Angeles"),
@@ -202,15 +205,16 @@ resource "lightstep_snooze_rule" "snooze1" {
 }
 `
 	resourceName := "lightstep_snooze_rule.snooze1"
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: ruleConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSnoozeRuleExists(resourceName, &rule),
+					testAccCheckSnoozeRuleExists(p, resourceName, &rule),
 					resource.TestCheckResourceAttr(resourceName, "title", "Snooze Test"),
 					resource.TestCheckResourceAttr(resourceName, "scope.0.basic.0.scope_filter.0.alert_ids.0", "alert1"),
 					resource.TestCheckResourceAttr(resourceName, "schedule.0.recurring.0.timezone", "America/Los_Angeles"),
@@ -224,7 +228,7 @@ resource "lightstep_snooze_rule" "snooze1" {
 	})
 }
 
-func testAccCheckSnoozeRuleExists(resourceName string, rule *client.SnoozeRuleWithID) resource.TestCheckFunc {
+func testAccCheckSnoozeRuleExists(p *schema.Provider, resourceName string, rule *client.SnoozeRuleWithID) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfSnoozeRule, ok := s.RootModule().Resources[resourceName]
 		if !ok {
@@ -235,7 +239,7 @@ func testAccCheckSnoozeRuleExists(resourceName string, rule *client.SnoozeRuleWi
 			return fmt.Errorf("id is not set")
 		}
 
-		providerClient := testAccProvider.Meta().(*client.Client)
+		providerClient := p.Meta().(*providerMeta).Client
 		r, err := providerClient.GetSnoozeRule(context.Background(), testProject, tfSnoozeRule.Primary.ID)
 		if err != nil {
 			return err
@@ -275,10 +279,11 @@ resource "lightstep_snooze_rule" "snooze1" {
   }
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccMetricConditionDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccMetricConditionDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config:      ruleConfig,
@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
@@ -56,7 +55,7 @@ func resourceStream() *schema.Resource {
 func resourceStreamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		origQuery := d.Get("query").(string)
 		stream, err := c.CreateStream(
@@ -96,21 +95,12 @@ func resourceStreamCreate(ctx context.Context, d *schema.ResourceData, m interfa
 func resourceStreamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	s, err := c.GetStream(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, isApiErr := err.(client.APIResponseCarrier)
-
-		if isApiErr {
-			resp := apiErr.GetHTTPResponse()
-			if resp == nil {
-				return diag.FromErr(fmt.Errorf("failed to get stream response: %v", err))
-			}
-
-			if resp.StatusCode == http.StatusNotFound {
-				d.SetId("")
-				return diags
-			}
+		if client.IsNotFound(err) {
+			d.SetId("")
+			return diags
 		}
 
 		return diag.FromErr(fmt.Errorf("failed to get stream: %v", err))
@@ -124,7 +114,7 @@ func resourceStreamRead(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	s := client.Stream{
 		Type: "stream",
@@ -144,9 +134,9 @@ func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 func resourceStreamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := c.DeleteStream(ctx, d.Get("project_name").(string), d.Id()); err != nil {
-		if apiClientError, ok := err.(client.APIResponseCarrier); ok && apiClientError.GetStatusCode() == http.StatusConflict {
+		if client.IsConflict(err) {
 			// Lightstep didn't delete the stream itself because there are other resources
 			// (usually alerts) that depend on it. However, that relationship is often implicit,
 			// and thus opaque to the terraform user, so we do our best to satisfy the user's
@@ -165,7 +155,7 @@ func resourceStreamDelete(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceStreamImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
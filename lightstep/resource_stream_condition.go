@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/lightstep/terraform-provider-lightstep/client"
@@ -50,7 +49,7 @@ func resourceStreamCondition() *schema.Resource {
 func resourceStreamConditionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	condition, err := c.CreateStreamCondition(
 		ctx,
@@ -74,20 +73,15 @@ func resourceStreamConditionCreate(ctx context.Context, d *schema.ResourceData,
 func resourceStreamConditionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	condition, err := c.GetStreamCondition(ctx, d.Get("project_name").(string), d.Id())
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get stream condition: %v", err))
-		}
-
-		if apiErr.GetHTTPResponse().StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get stream condition: %v", apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get stream condition: %v", err))
 	}
 
 	if err := setResourceDataFromStreamCondition(d, *condition); err != nil {
@@ -100,7 +94,7 @@ func resourceStreamConditionRead(ctx context.Context, d *schema.ResourceData, m
 func resourceStreamConditionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	if err := c.DeleteStreamCondition(ctx, d.Get("project_name").(string), d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete stream condition: %v", err))
 	}
@@ -111,7 +105,7 @@ func resourceStreamConditionDelete(ctx context.Context, d *schema.ResourceData,
 func resourceStreamConditionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 	attrs := client.StreamConditionAttributes{
 		Name:               d.Get("condition_name").(string),
 		EvaluationWindowMS: d.Get("evaluation_window_ms").(int),
@@ -131,7 +125,7 @@ func resourceStreamConditionUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceStreamConditionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), ".")
 	if len(ids) != 2 {
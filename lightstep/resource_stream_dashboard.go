@@ -3,7 +3,6 @@ package lightstep
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/lightstep/terraform-provider-lightstep/client"
@@ -43,7 +42,7 @@ func resourceStreamDashboard() *schema.Resource {
 }
 
 func resourceStreamDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 
 	projectName := d.Get("project_name").(string)
 	dashboardName := d.Get("dashboard_name").(string)
@@ -61,24 +60,19 @@ func resourceStreamDashboardCreate(ctx context.Context, d *schema.ResourceData,
 func resourceStreamDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	projectName := d.Get("project_name").(string)
 	resourceId := d.Id()
 
 	dashboard, err := c.GetDashboard(ctx, projectName, resourceId)
 	if err != nil {
-		apiErr, ok := err.(client.APIResponseCarrier)
-		if !ok {
-			return diag.FromErr(fmt.Errorf("failed to get stream dashboard for [project: %v; resource_id: %v]: %v", projectName, resourceId, err))
-		}
-
-		if apiErr.GetStatusCode() == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			d.SetId("")
 			return diags
 		}
 
-		return diag.FromErr(fmt.Errorf("failed to get stream dashboard for [project: %v; resource_id: %v]: %v", projectName, resourceId, apiErr))
+		return diag.FromErr(fmt.Errorf("failed to get stream dashboard for [project: %v; resource_id: %v]: %v", projectName, resourceId, err))
 	}
 
 	if err := setResourceDataFromStreamDashboard(d, *dashboard); err != nil {
@@ -91,7 +85,7 @@ func resourceStreamDashboardRead(ctx context.Context, d *schema.ResourceData, m
 func resourceStreamDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 	projectName := d.Get("project_name").(string)
 	dashboardName := d.Get("dashboard_name").(string)
 	resourceId := d.Id()
@@ -107,7 +101,7 @@ func resourceStreamDashboardUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceStreamDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 	projectName := d.Get("project_name").(string)
 	resourceId := d.Id()
 
@@ -120,7 +114,7 @@ func resourceStreamDashboardDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceStreamDashboardImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*client.Client)
+	client := m.(*providerMeta).Client
 
 	resourceId := d.Id()
 	ids := strings.Split(resourceId, ".")
@@ -11,6 +11,7 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -55,23 +56,24 @@ resource "lightstep_stream" "aggie_errors" {
   ]
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccStreamDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccStreamDestroy(p),
 		// each step is akin to running a `terraform apply`
 		Steps: []resource.TestStep{
 			{
 				Config: badQuery,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.aggie_errors", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.aggie_errors", &stream),
 				),
 				ExpectError: regexp.MustCompile("InvalidArgument"),
 			},
 			{
 				Config: streamConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.aggie_errors", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.aggie_errors", &stream),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "stream_name", "Aggie Errors"),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "query", "service IN (\"aggie\") AND \"error\" IN (\"true\")"),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "custom_data.0.name", "object1"),
@@ -81,7 +83,7 @@ resource "lightstep_stream" "aggie_errors" {
 			{
 				Config: updatedNameQuery,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.aggie_errors", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.aggie_errors", &stream),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "stream_name", "Errors (All)"),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "query", "\"error\" IN (\"true\")"),
 					resource.TestCheckResourceAttr("lightstep_stream.aggie_errors", "custom_data.0.name", "object1"),
@@ -93,9 +95,10 @@ resource "lightstep_stream" "aggie_errors" {
 }
 
 func TestAccStreamImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -165,25 +168,26 @@ resource "lightstep_alert" "web_errors_alert" {
 
 	var streamCreatedResource1, streamCreatedResource2, streamUpdatedResource client.Stream
 	var alertCreatedResource, alertUpdatedResource client.UnifiedCondition
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccStreamDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccStreamDestroy(p),
 		// each step is akin to running a `terraform apply`
 		Steps: []resource.TestStep{
 			// create a new stream
 			{
 				Config: streamConfig1,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream."+streamResourceName1, &streamCreatedResource1),
+					testAccCheckStreamExists(p, "lightstep_stream."+streamResourceName1, &streamCreatedResource1),
 				),
 			},
 			// create a unified alert that implicitly depends on the stream
 			{
 				Config: streamConfig1 + alertConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream."+streamResourceName1, &streamUpdatedResource),
-					testAccCheckLightstepAlertExists("lightstep_alert.web_errors_alert", &alertCreatedResource),
+					testAccCheckStreamExists(p, "lightstep_stream."+streamResourceName1, &streamUpdatedResource),
+					testAccCheckLightstepAlertExists(p, "lightstep_alert.web_errors_alert", &alertCreatedResource),
 				),
 			},
 			// try to delete the stream
@@ -192,11 +196,11 @@ resource "lightstep_alert" "web_errors_alert" {
 				Config: alertConfig,
 				Check: resource.ComposeTestCheckFunc(
 					// verify that terraform still knows about the alert and that it still exists in Lightstep
-					testAccCheckLightstepAlertExists("lightstep_alert.web_errors_alert", &alertUpdatedResource),
+					testAccCheckLightstepAlertExists(p, "lightstep_alert.web_errors_alert", &alertUpdatedResource),
 
 					// make sure the stream was removed from the terraform state...
 					func(state *terraform.State) error {
-						err := testAccCheckStreamExists("lightstep_stream."+streamResourceName1, &streamUpdatedResource)(state)
+						err := testAccCheckStreamExists(p, "lightstep_stream."+streamResourceName1, &streamUpdatedResource)(state)
 						if err != nil {
 							if strings.Contains(err.Error(), "not found: lightstep_stream."+streamResourceName1) {
 								return nil
@@ -214,7 +218,7 @@ resource "lightstep_alert" "web_errors_alert" {
 							return errors.New("unexpected empty alert ID")
 						}
 
-						providerClient := testAccProvider.Meta().(*client.Client)
+						providerClient := p.Meta().(*providerMeta).Client
 						stream, err := providerClient.GetStream(ctx, testProject, streamCreatedResource1.ID)
 						if err != nil {
 							return errors.New(fmt.Sprintf("stream not found: %v", err))
@@ -236,7 +240,7 @@ resource "lightstep_alert" "web_errors_alert" {
 				// create a new stream resource using the original query (it should import/rename the existing stream)
 				Config: streamConfig2,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream."+streamResourceName2, &streamCreatedResource2),
+					testAccCheckStreamExists(p, "lightstep_stream."+streamResourceName2, &streamCreatedResource2),
 					func(state *terraform.State) error {
 						if len(streamCreatedResource1.ID) == 0 {
 							return errors.New("unexpected empty stream ID")
@@ -266,6 +270,7 @@ resource "lightstep_alert" "web_errors_alert" {
 }
 
 func TestAccStreamQueryNormalization(t *testing.T) {
+	p := testAccProviderFor(t)
 	var stream client.Stream
 
 	query1 := `
@@ -292,14 +297,14 @@ func TestAccStreamQueryNormalization(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccStreamDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccStreamDestroy(p),
 		// each step is akin to running a `terraform apply`
 		Steps: []resource.TestStep{
 			{
 				Config: query1,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.query_one", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.query_one", &stream),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "stream_name", "Query 1"),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "query", "\"error\" IN (\"true\") AND service IN (\"api\")"),
 				),
@@ -307,7 +312,7 @@ func TestAccStreamQueryNormalization(t *testing.T) {
 			{
 				Config: query1updated,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.query_one", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.query_one", &stream),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "stream_name", "Query One"),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "query", "\"error\" IN (\"true\") AND service IN (\"api\")"),
 				),
@@ -315,7 +320,7 @@ func TestAccStreamQueryNormalization(t *testing.T) {
 			{
 				Config: query1updatedQuery,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckStreamExists("lightstep_stream.query_one", &stream),
+					testAccCheckStreamExists(p, "lightstep_stream.query_one", &stream),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "stream_name", "Query One"),
 					resource.TestCheckResourceAttr("lightstep_stream.query_one", "query", "service IN (\"api\") AND \"error\" IN (\"true\")"),
 				),
@@ -324,7 +329,7 @@ func TestAccStreamQueryNormalization(t *testing.T) {
 	})
 }
 
-func testAccCheckStreamExists(resourceName string, stream *client.Stream) resource.TestCheckFunc {
+func testAccCheckStreamExists(p *schema.Provider, resourceName string, stream *client.Stream) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get stream from TF state
 		tfStream, ok := s.RootModule().Resources[resourceName]
@@ -337,7 +342,7 @@ func testAccCheckStreamExists(resourceName string, stream *client.Stream) resour
 		}
 
 		// get stream from LS
-		client := testAccProvider.Meta().(*client.Client)
+		client := p.Meta().(*providerMeta).Client
 		str, err := client.GetStream(context.Background(), testProject, tfStream.Primary.ID)
 		if err != nil {
 			return err
@@ -350,22 +355,24 @@ func testAccCheckStreamExists(resourceName string, stream *client.Stream) resour
 }
 
 // confirms that streams created during test run have been destroyed
-func testAccStreamDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
+func testAccStreamDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
 
-	for _, resource := range s.RootModule().Resources {
-		if resource.Type != "stream" {
-			continue
-		}
+		for _, resource := range s.RootModule().Resources {
+			if resource.Type != "stream" {
+				continue
+			}
 
-		s, err := conn.GetStream(context.Background(), testProject, resource.Primary.ID)
-		if err == nil {
-			if s.ID == resource.Primary.ID {
-				return fmt.Errorf("stream with ID (%v) still exists.", resource.Primary.ID)
+			s, err := conn.GetStream(context.Background(), testProject, resource.Primary.ID)
+			if err == nil {
+				if s.ID == resource.Primary.ID {
+					return fmt.Errorf("stream with ID (%v) still exists.", resource.Primary.ID)
+				}
 			}
+
 		}
 
+		return nil
 	}
-
-	return nil
 }
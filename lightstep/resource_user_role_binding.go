@@ -12,13 +12,23 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 )
 
+// userRoleNames are the roles users can be granted, organization or project wide.
+var userRoleNames = []string{
+	"Organization Admin",
+	"Organization Editor",
+	"Organization Viewer",
+	"Organization Restricted Member",
+	"Project Editor",
+	"Project Viewer",
+}
+
 func resourceUserRoleBinding() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a [Lightstep Role Binding](https://api-docs.lightstep.com/reference/RoleBinding). Use this resource to manage users' roles in Lightstep. For conceptual information about managing users and roles, visit [Lightstep's documentation](https://docs.lightstep.com/docs/create-lightstep-users).
 
 You can assign users [organization-level](https://docs.lightstep.com/docs/roles-and-permissions#organization-roles) or [project-level](https://docs.lightstep.com/docs/roles-and-permissions#project-roles) roles. You can't assign users to a more restrictive project role than their organization role.
 
-**NOTE**: This Terraform resource is authoritative. If you don't declare a user in a Terraform resource, that user will lose any previously assigned roles in the specified organization and project. 
+**NOTE**: This Terraform resource is authoritative. If you don't declare a user in a Terraform resource, that user will lose any previously assigned roles in the specified organization and project. To grant a role to a single user without managing the other users of the role, use ` + "`lightstep_user_role_binding_member`" + ` instead.
 
 The valid organization roles are:
 * Organization Admin
@@ -46,18 +56,11 @@ We suggest these changes be made in two steps.
 		},
 		Schema: map[string]*schema.Schema{
 			"role": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true, // changing role or project requires a new tf resource to ensure permissions are properly removed.
-				Description: "Role's name being granted with this role binding.",
-				ValidateFunc: validation.StringInSlice([]string{
-					"Organization Admin",
-					"Organization Editor",
-					"Organization Viewer",
-					"Organization Restricted Member",
-					"Project Editor",
-					"Project Viewer",
-				}, false),
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true, // changing role or project requires a new tf resource to ensure permissions are properly removed.
+				Description:  "Role's name being granted with this role binding.",
+				ValidateFunc: validation.StringInSlice(userRoleNames, false),
 			},
 			"project": {
 				Type:        schema.TypeString,
@@ -123,7 +126,7 @@ func setUserRoleBindingFromResource(d *schema.ResourceData, userRoleBinding clie
 }
 
 func resourceUserRoleBindingCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	// Read the proposed plan
 	userRoleBinding := getUserRoleBindingFromResource(ctx, d)
@@ -148,7 +151,7 @@ func resourceUserRoleBindingCreateOrUpdate(ctx context.Context, d *schema.Resour
 // When called by a Create or Update context, it will read data from the terraform plan.
 func resourceUserRoleBindingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	// Read the resource from data.
 	userRoleBinding := getUserRoleBindingFromResource(ctx, d)
@@ -169,7 +172,7 @@ func resourceUserRoleBindingRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceUserRoleBindingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	// Read from the terraform state
 	userRoleBinding := getUserRoleBindingFromResource(ctx, d)
@@ -187,7 +190,7 @@ func resourceUserRoleBindingDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceUserRoleBindingImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
 	ids := strings.Split(d.Id(), "/")
 	if len(ids) < 2 {
@@ -0,0 +1,170 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+func resourceUserRoleBindingMember() *schema.Resource {
+	return &schema.Resource{
+		Description: `Grants a [Lightstep role](https://docs.lightstep.com/docs/roles-and-permissions) to a single user, in the organization or in a project.
+
+**NOTE**: Unlike ` + "`lightstep_user_role_binding`" + `, this Terraform resource is not authoritative: users granted the role elsewhere keep it, so several configurations can each grant the same role to their own users. Don't use it together with a ` + "`lightstep_user_role_binding`" + ` for the same role and project, since that resource removes every user it doesn't declare. The Lightstep API can only replace all the users of a role, so granting or revoking it reads the users first and writes them back. A user granted the same role by someone else in the moment between that read and write, e.g. in the UI or by another Terraform run, loses it again. Users dropped by a concurrent write made after the resource's own write are added back, but this case can't be detected.
+
+The valid organization roles are:
+* Organization Admin
+* Organization Editor
+* Organization Viewer
+* Organization Restricted Member
+
+
+The valid project roles are:
+* Project Editor
+* Project Viewer
+`,
+		CreateContext: resourceUserRoleBindingMemberCreate,
+		ReadContext:   resourceUserRoleBindingMemberRead,
+		DeleteContext: resourceUserRoleBindingMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserRoleBindingMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Role's name being granted to the user.",
+				ValidateFunc: validation.StringInSlice(userRoleNames, false),
+			},
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the project where this role will be applied; if omitted the role will be applied to the organization",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "User being granted the role.",
+			},
+		},
+	}
+}
+
+func resourceUserRoleBindingMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	project, role, user := d.Get("project").(string), d.Get("role").(string), d.Get("user").(string)
+
+	_, err := c.ModifyRoleBinding(ctx, project, role, func(users []string) []string {
+		if hasUser(users, user) {
+			return users
+		}
+		return append(users, user)
+	})
+	if err != nil {
+		return handleAPIError(err, d, "create user role binding member")
+	}
+
+	// Save this resource with an ID with the following format "org_name/role_name/project_name/user",
+	// where project name is optional.
+	d.SetId(fmt.Sprintf("%s/%s/%s", c.OrgName(), client.RoleBinding{RoleName: role, ProjectName: project}.ID(), user))
+
+	return resourceUserRoleBindingMemberRead(ctx, d, m)
+}
+
+func resourceUserRoleBindingMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	project, role, user := d.Get("project").(string), d.Get("role").(string), d.Get("user").(string)
+
+	binding, err := c.ListRoleBinding(ctx, project, role)
+	if err != nil {
+		return handleAPIError(err, d, "get user role binding")
+	}
+
+	// the role was revoked outside of this resource, plan to grant it again
+	if !hasUser(binding.Users, user) {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceUserRoleBindingMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*providerMeta).Client
+	project, role, user := d.Get("project").(string), d.Get("role").(string), d.Get("user").(string)
+
+	// only remove this user, the others may be managed elsewhere
+	_, err := c.ModifyRoleBinding(ctx, project, role, func(users []string) []string {
+		var remaining []string
+		for _, u := range users {
+			if !strings.EqualFold(u, user) {
+				remaining = append(remaining, u)
+			}
+		}
+		return remaining
+	})
+	if err != nil {
+		return handleAPIError(err, d, "delete user role binding member")
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceUserRoleBindingMemberImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*providerMeta).Client
+
+	ids := strings.Split(d.Id(), "/")
+	if len(ids) != 3 && len(ids) != 4 {
+		return nil, fmt.Errorf("user role binding member id should be in the following format: {organization-name}/{role-name}/{user} or {organization-name}/{role-name}/{project-name}/{user}")
+	}
+
+	orgName, roleName, user := ids[0], ids[1], ids[len(ids)-1]
+
+	var projectName string
+	if len(ids) == 4 {
+		projectName = ids[2]
+	}
+
+	if c.OrgName() != orgName {
+		return nil, fmt.Errorf("lightstep terraform provider is configured to organization %s but it is trying to import resource bindings from organization %s: %s", c.OrgName(), orgName, d.Id())
+	}
+
+	binding, err := c.ListRoleBinding(ctx, projectName, roleName)
+	if err != nil {
+		return []*schema.ResourceData{}, fmt.Errorf("failed to get user role binding: %v", err)
+	}
+	if !hasUser(binding.Users, user) {
+		return nil, fmt.Errorf("user %s doesn't have role %s: %s", user, roleName, d.Id())
+	}
+
+	if err := d.Set("role", roleName); err != nil {
+		return nil, fmt.Errorf("error to set role resource field: %v", err)
+	}
+	if err := d.Set("project", projectName); err != nil {
+		return nil, fmt.Errorf("error to set project resource field: %v", err)
+	}
+	if err := d.Set("user", user); err != nil {
+		return nil, fmt.Errorf("error to set user resource field: %v", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// hasUser reports whether users contains user. Users are email addresses, so the case is ignored.
+func hasUser(users []string, user string) bool {
+	for _, u := range users {
+		if strings.EqualFold(u, user) {
+			return true
+		}
+	}
+	return false
+}
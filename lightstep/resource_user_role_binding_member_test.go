@@ -0,0 +1,71 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoleBindingMember_fakeAPI(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_user_role_binding_member"]
+	c := p.Meta().(*providerMeta).Client
+	ctx := context.Background()
+
+	// granted outside of Terraform
+	_, err := c.UpdateRoleBinding(ctx, "shared", "Project Editor", "existing@example.com")
+	require.NoError(t, err)
+
+	// members of the same binding created in parallel all keep the role
+	members := make([]*schema.ResourceData, 3)
+	var wg sync.WaitGroup
+	for i := range members {
+		members[i] = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"project": "shared",
+			"role":    "Project Editor",
+			"user":    fmt.Sprintf("team%d@example.com", i),
+		})
+		wg.Add(1)
+		go func(d *schema.ResourceData) {
+			defer wg.Done()
+			assert.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+		}(members[i])
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"existing@example.com", "team0@example.com", "team1@example.com", "team2@example.com"},
+		server.RoleBinding("shared", "Project Editor").Users)
+	assert.Equal(t, server.Org+"/Project Editor/shared/team0@example.com", members[0].Id())
+
+	// deleting a member only revokes its own user
+	require.False(t, r.DeleteContext(ctx, members[1], p.Meta()).HasError())
+	assert.Equal(t, []string{"existing@example.com", "team0@example.com", "team2@example.com"},
+		server.RoleBinding("shared", "Project Editor").Users)
+
+	// revoked outside of Terraform
+	_, err = c.UpdateRoleBinding(ctx, "shared", "Project Editor", "existing@example.com", "team0@example.com")
+	require.NoError(t, err)
+	require.False(t, r.ReadContext(ctx, members[2], p.Meta()).HasError())
+	assert.Empty(t, members[2].Id())
+	require.False(t, r.ReadContext(ctx, members[0], p.Meta()).HasError())
+	assert.NotEmpty(t, members[0].Id())
+
+	imported := r.Data(nil)
+	imported.SetId(server.Org + "/Project Editor/shared/existing@example.com")
+	states, err := r.Importer.StateContext(ctx, imported, p.Meta())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "shared", states[0].Get("project"))
+	assert.Equal(t, "Project Editor", states[0].Get("role"))
+	assert.Equal(t, "existing@example.com", states[0].Get("user"))
+
+	imported = r.Data(nil)
+	imported.SetId(server.Org + "/Organization Admin/existing@example.com")
+	_, err = r.Importer.StateContext(ctx, imported, p.Meta())
+	assert.EqualError(t, err, "user existing@example.com doesn't have role Organization Admin: "+imported.Id())
+}
@@ -104,9 +104,10 @@ resource "lightstep_user_role_binding" "proj_viewer" {
 }	
 `
 
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		ProviderFactories: testAccProviderFactories(p),
 		Steps: []resource.TestStep{
 			{
 				Config: allRestricted,
@@ -180,9 +181,10 @@ resource "lightstep_user_role_binding" "proj_viewer" {
 }
 
 func TestUserRoleBindingImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		ProviderFactories: testAccProviderFactories(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -7,6 +7,7 @@ import (
 
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,6 +17,7 @@ func resourceWebhookDestination() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWebhookDestinationCreate,
 		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceWebhookDestinationUpdate,
 		DeleteContext: resourceDestinationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceWebhookDestinationImport,
@@ -29,70 +31,147 @@ func resourceWebhookDestination() *schema.Resource {
 			"destination_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Name of the webhook destination",
 			},
 			"url": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				Description:  "Webhook URL",
 				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
 			},
 			"template": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Webhook payload body text template. Used for customing webhook messages",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateWebhookTemplate,
+				Description:      "Webhook payload body text template. Used for customing webhook messages",
 			},
 			"custom_headers": {
 				Type:        schema.TypeMap,
 				Description: "Custom HTTP headers for the webhook request",
 				Optional:    true,
-				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"auth_header": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "HTTP headers carrying credentials, such as `Authorization`, whose values are sent to Lightstep without being stored in the state. Requires Terraform 1.11 or later",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Header name",
+						},
+						"value_wo": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							WriteOnly:   true,
+							Description: "Header value",
+						},
+					},
+				},
+			},
+			"auth_headers_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change to send new `auth_header` values to Lightstep. Write-only values aren't stored, so changing them alone doesn't update the destination",
+			},
+			"signing_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret used to sign webhook payloads with HMAC-SHA256, so receivers can verify they come from Lightstep. It's never read back from Lightstep; removing it from the configuration removes it from the destination",
 			},
 		},
 	}
 }
 
 func resourceWebhookDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
+	attrs, diags := buildWebhookAttributes(d)
+	if diags.HasError() {
+		return diags
+	}
 	dest := client.Destination{
-		Type: "destination",
+		Type:       "destination",
+		Attributes: attrs,
 	}
+	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string), dest)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create webhook destination %s: %v", destination, err))
+	}
+
+	d.SetId(destination.ID)
+	return resourceDestinationRead(ctx, d, m)
+}
+
+func resourceWebhookDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	attrs, diags := buildWebhookAttributes(d)
+	if diags.HasError() {
+		return diags
+	}
+	return updateDestination(ctx, d, m, attrs)
+}
+
+func buildWebhookAttributes(d *schema.ResourceData) (client.WebhookAttributes, diag.Diagnostics) {
 	attrs := client.WebhookAttributes{
 		Name:            d.Get("destination_name").(string),
 		DestinationType: "webhook",
 		URL:             d.Get("url").(string),
 	}
 
+	// the secret is never read back, so removing it from the configuration has to clear it
+	// explicitly; an omitted secret would be kept
+	if secret := d.Get("signing_secret").(string); secret != "" || d.HasChange("signing_secret") {
+		attrs.SigningSecret = &secret
+	}
+
 	headers, ok := d.GetOk("custom_headers")
 	if ok {
 		attrs.CustomHeaders = headers.(map[string]interface{})
 	}
 
+	authHeaders := d.Get("auth_header").([]interface{})
+	if len(authHeaders) > 0 {
+		merged := map[string]interface{}{}
+		for name, value := range attrs.CustomHeaders {
+			merged[name] = value
+		}
+		for i, h := range authHeaders {
+			value, diags := writeOnlyString(d, cty.GetAttrPath("auth_header").IndexInt(i).GetAttr("value_wo"))
+			if diags.HasError() {
+				return attrs, diags
+			}
+			merged[h.(map[string]interface{})["name"].(string)] = value
+		}
+		attrs.CustomHeaders = merged
+	}
+
 	template, ok := d.GetOk("template")
 	if ok {
 		attrs.Template = template.(string)
 	}
+	return attrs, nil
+}
 
-	dest.Attributes = attrs
-	destination, err := c.CreateDestination(ctx, d.Get("project_name").(string), dest)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create webhook destination %s: %v", destination, err))
+// isWebhookAuthHeader reports whether a header is set by one of the destination's auth_header blocks.
+func isWebhookAuthHeader(d *schema.ResourceData, name string) bool {
+	for _, h := range d.Get("auth_header").([]interface{}) {
+		if strings.EqualFold(h.(map[string]interface{})["name"].(string), name) {
+			return true
+		}
 	}
-
-	d.SetId(destination.ID)
-	return resourceDestinationRead(ctx, d, m)
+	return false
 }
 
 func resourceWebhookDestinationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+	c := m.(*providerMeta).Client
 
-	ids := strings.Split(d.Id(), ".")
-	if len(ids) != 2 {
-		return []*schema.ResourceData{}, fmt.Errorf("error importing lightstep_webhook_destination. Expecting an  ID formed as '<lightstep_project>.<lightstep_destination_ID>'")
+	ids, err := splitID("lightstep_webhook_destination", d.Id())
+	if err != nil {
+		return nil, err
 	}
 
 	project, id := ids[0], ids[1]
@@ -106,25 +185,8 @@ func resourceWebhookDestinationImport(ctx context.Context, d *schema.ResourceDat
 		return []*schema.ResourceData{}, fmt.Errorf("unable to set project_name resource field: %v", err)
 	}
 
-	attributes := dest.Attributes.(map[string]interface{})
-	if err := d.Set("destination_name", attributes["name"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set destination_name resource field: %v", err)
-	}
-
-	if err := d.Set("url", attributes["url"]); err != nil {
-		return []*schema.ResourceData{}, fmt.Errorf("unable to set url resource field: %v", err)
-	}
-
-	if attributes["template"] != nil && len(attributes["template"].(string)) > 0 {
-		if err := d.Set("template", attributes["template"]); err != nil {
-			return []*schema.ResourceData{}, fmt.Errorf("unable to set template resource field: %v", err)
-		}
-	}
-
-	if len(attributes["custom_headers"].(map[string]interface{})) > 0 {
-		if err := d.Set("custom_headers", attributes["custom_headers"]); err != nil {
-			return []*schema.ResourceData{}, fmt.Errorf("unable to set custom_headers resource field: %v", err)
-		}
+	if err := setDestinationAttributes(d, dest); err != nil {
+		return []*schema.ResourceData{}, err
 	}
 
 	return []*schema.ResourceData{d}, nil
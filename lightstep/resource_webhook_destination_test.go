@@ -9,7 +9,10 @@ import (
 	"github.com/lightstep/terraform-provider-lightstep/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccWebhookDestination(t *testing.T) {
@@ -33,22 +36,23 @@ resource "lightstep_webhook_destination" "webhook" {
   }
 }
 `
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccWebhookDestinationDestroy,
+		Providers:    testAccProviders(p),
+		CheckDestroy: testAccWebhookDestinationDestroy(p),
 		Steps: []resource.TestStep{
 			{
 				Config: missingExpressionConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckWebhookDestinationExists("lightstep_webhook_destination.missing_webhook", &destination),
+					testAccCheckWebhookDestinationExists(p, "lightstep_webhook_destination.missing_webhook", &destination),
 				),
 				ExpectError: regexp.MustCompile("The argument \"url\" is required, but no definition was found."),
 			},
 			{
 				Config: destinationConfig,
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckWebhookDestinationExists("lightstep_webhook_destination.webhook", &destination),
+					testAccCheckWebhookDestinationExists(p, "lightstep_webhook_destination.webhook", &destination),
 					resource.TestCheckResourceAttr("lightstep_webhook_destination.webhook", "destination_name", "very important webhook"),
 					resource.TestCheckResourceAttr("lightstep_webhook_destination.webhook", "url", "https://www.downforeveryoneorjustme.com"),
 				),
@@ -59,9 +63,10 @@ resource "lightstep_webhook_destination" "webhook" {
 }
 
 func TestAccWebhookDestinationImport(t *testing.T) {
+	p := testAccProviderFor(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		Providers: testAccProviders(p),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -85,7 +90,7 @@ resource "lightstep_webhook_destination" "webhook" {
 	})
 }
 
-func testAccCheckWebhookDestinationExists(resourceName string, destination *client.Destination) resource.TestCheckFunc {
+func testAccCheckWebhookDestinationExists(p *schema.Provider, resourceName string, destination *client.Destination) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// get destination from TF state
 		tfDestination, ok := s.RootModule().Resources[resourceName]
@@ -98,7 +103,7 @@ func testAccCheckWebhookDestinationExists(resourceName string, destination *clie
 		}
 
 		// get destination from LS
-		client := testAccProvider.Meta().(*client.Client)
+		client := p.Meta().(*providerMeta).Client
 		d, err := client.GetDestination(context.Background(), testProject, tfDestination.Primary.ID)
 		if err != nil {
 			return err
@@ -109,20 +114,169 @@ func testAccCheckWebhookDestinationExists(resourceName string, destination *clie
 	}
 }
 
-func testAccWebhookDestinationDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*client.Client)
-	for _, resource := range s.RootModule().Resources {
-		if resource.Type != "lightstep_webhook_destination" {
-			continue
-		}
+func testAccWebhookDestinationDestroy(p *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := p.Meta().(*providerMeta).Client
+		for _, resource := range s.RootModule().Resources {
+			if resource.Type != "lightstep_webhook_destination" {
+				continue
+			}
 
-		s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
-		if err == nil {
-			if s.ID == resource.Primary.ID {
-				return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+			s, err := conn.GetDestination(context.Background(), testProject, resource.Primary.ID)
+			if err == nil {
+				if s.ID == resource.Primary.ID {
+					return fmt.Errorf("destination with ID (%v) still exists.", resource.Primary.ID)
+				}
 			}
+
 		}
+		return nil
+	}
+}
+
+func TestWebhookDestination_fakeAPI_drift(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_webhook_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "hook",
+		"url":              "https://example.com/hook",
+		"template":         `{"text": "alert"}`,
+		"custom_headers":   map[string]interface{}{"x-team": "core"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	// edit the destination outside of Terraform
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	attributes := stored["attributes"].(map[string]interface{})
+	attributes["url"] = "https://example.com/other"
+	attributes["custom_headers"] = map[string]interface{}{"x-team": "web"}
+	server.Put("offline", "destinations", stored)
+
+	require.False(t, r.ReadContext(ctx, d, p.Meta()).HasError())
+	assert.Equal(t, "https://example.com/other", d.Get("url"))
+	assert.Equal(t, map[string]interface{}{"x-team": "web"}, d.Get("custom_headers"))
+
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "url")
+	assert.Contains(t, diff.Attributes, "custom_headers.x-team")
+
+	imported := r.Data(nil)
+	imported.SetId("offline." + d.Id())
+	states, err := r.Importer.StateContext(ctx, imported, p.Meta())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "hook", states[0].Get("destination_name"))
+	assert.Equal(t, `{"text": "alert"}`, states[0].Get("template"))
+}
+
+func TestWebhookDestination_fakeAPI_update(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_webhook_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "hook",
+		"url":              "https://example.com/hook",
+		"custom_headers":   map[string]interface{}{"authorization": "token old"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+	id := d.Id()
+
+	// rotating a header updates the destination in place
+	config["custom_headers"] = map[string]interface{}{"authorization": "token new"}
+	config["template"] = `{"text": "alert"}`
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+
+	state, diags := r.Apply(ctx, d.State(), diff, p.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, id, state.ID)
+
+	stored, ok := server.Get("offline", "destinations", id)
+	require.True(t, ok)
+	attributes := stored["attributes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"authorization": "token new"}, attributes["custom_headers"])
+	assert.Equal(t, `{"text": "alert"}`, attributes["template"])
+}
+
+func TestWebhookDestination_fakeAPI_secrets(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_webhook_destination"]
+	ctx := context.Background()
+
+	assert.True(t, r.Schema["custom_headers"].Sensitive)
+	assert.True(t, r.Schema["signing_secret"].Sensitive)
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "hook",
+		"url":              "https://example.com/hook",
+		"custom_headers":   map[string]interface{}{"x-team": "core"},
+		"signing_secret":   "hmac-key",
+		"auth_header":      []interface{}{map[string]interface{}{"name": "Authorization"}},
+	}
+	d := testResourceDataWithWriteOnly(t, r, config, map[string]interface{}{
+		"auth_header": []interface{}{map[string]interface{}{"name": "Authorization", "value_wo": "Bearer secret"}},
+	})
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	attributes := stored["attributes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"x-team": "core", "Authorization": "Bearer secret"}, attributes["custom_headers"])
+	assert.Equal(t, "hmac-key", attributes["signing_secret"])
+
+	// the auth header only lives in Lightstep, so it doesn't show up as drift
+	assert.Equal(t, map[string]interface{}{"x-team": "core"}, d.Get("custom_headers"))
+	assert.Equal(t, "hmac-key", d.Get("signing_secret"))
+	assert.Empty(t, d.Get("auth_header.0.value_wo"))
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+}
 
+func TestWebhookDestination_fakeAPI_removeSigningSecret(t *testing.T) {
+	p, server := testFakeAPIProvider(t)
+	r := p.ResourcesMap["lightstep_webhook_destination"]
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"project_name":     "offline",
+		"destination_name": "hook",
+		"url":              "https://example.com/hook",
+		"signing_secret":   "hmac-key",
 	}
-	return nil
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.False(t, r.CreateContext(ctx, d, p.Meta()).HasError())
+
+	delete(config, "signing_secret")
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), p.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+
+	_, diags := r.Apply(ctx, d.State(), diff, p.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	// the secret is cleared explicitly rather than left out of the request
+	stored, ok := server.Get("offline", "destinations", d.Id())
+	require.True(t, ok)
+	secret, ok := stored["attributes"].(map[string]interface{})["signing_secret"]
+	assert.True(t, ok)
+	assert.Equal(t, "", secret)
 }
@@ -0,0 +1,170 @@
+package lightstep
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+	"github.com/lightstep/terraform-provider-lightstep/version"
+)
+
+const (
+	tracerName = "github.com/lightstep/terraform-provider-lightstep/lightstep"
+
+	// ResourceTypeKey is the span attribute holding the Terraform resource or data source type
+	ResourceTypeKey = attribute.Key("terraform.resource_type")
+	// ResourceIDKey is the span attribute holding the ID of the resource being operated on
+	ResourceIDKey = attribute.Key("terraform.resource_id")
+)
+
+var (
+	tracingMu       sync.Mutex
+	tracingProvider *sdktrace.TracerProvider
+)
+
+// configureTracing installs a global TracerProvider exporting over OTLP/HTTP when an endpoint
+// is set in the provider block or through the standard OTEL_EXPORTER_OTLP_* environment
+// variables. It only takes effect once per process, so aliased providers share it.
+func configureTracing(ctx context.Context, endpoint string, headers map[string]string) error {
+	if endpoint == "" && os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return nil
+	}
+
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	if tracingProvider != nil {
+		return nil
+	}
+
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid OTLP endpoint %q: expected a URL such as http://localhost:4318", endpoint)
+		}
+		opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if u.Path != "" && u.Path != "/" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+	}
+	if len(headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("terraform-provider-lightstep"),
+		semconv.ServiceVersion(version.ProviderVersion),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to build tracing resource: %v", err)
+	}
+
+	setTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	))
+	return nil
+}
+
+// setTracerProvider makes tp the provider used by both the lightstep and client packages.
+// Callers must hold tracingMu.
+func setTracerProvider(tp *sdktrace.TracerProvider) {
+	tracingProvider = tp
+	otel.SetTracerProvider(tp)
+}
+
+// ShutdownTracing flushes any spans that haven't been exported yet. It is called once the
+// plugin stops serving.
+func ShutdownTracing(ctx context.Context) error {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	if tracingProvider == nil {
+		return nil
+	}
+	return tracingProvider.Shutdown(ctx)
+}
+
+// instrumentResource wraps the CRUD and import functions of r in spans named after the
+// resource type and operation, e.g. "lightstep_dashboard.create".
+func instrumentResource(typeName string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = traceCRUD(typeName, "create", r.CreateContext)
+	r.ReadContext = traceCRUD(typeName, "read", r.ReadContext)
+	r.UpdateContext = traceCRUD(typeName, "update", r.UpdateContext)
+	r.DeleteContext = traceCRUD(typeName, "delete", r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			ctx, span := startResourceSpan(ctx, typeName, "import", d)
+			defer span.End()
+
+			result, err := importState(ctx, d, m)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return result, err
+		}
+	}
+	return r
+}
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+func traceCRUD(typeName string, operation string, fn crudFunc) crudFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx, span := startResourceSpan(ctx, typeName, operation, d)
+		defer span.End()
+
+		diags := fn(ctx, d, m)
+		if d.Id() != "" {
+			span.SetAttributes(ResourceIDKey.String(d.Id()))
+		}
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				span.SetStatus(codes.Error, diagnostic.Summary)
+				break
+			}
+		}
+		return diags
+	}
+}
+
+func startResourceSpan(ctx context.Context, typeName string, operation string, d *schema.ResourceData) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{ResourceTypeKey.String(typeName)}
+	if project, ok := d.GetOk("project_name"); ok {
+		attrs = append(attrs, client.ProjectKey.String(project.(string)))
+	}
+	if d.Id() != "" {
+		attrs = append(attrs, ResourceIDKey.String(d.Id()))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s.%s", typeName, operation),
+		trace.WithAttributes(attrs...),
+	)
+}
@@ -0,0 +1,75 @@
+package lightstep
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/lightstep/terraform-provider-lightstep/client"
+)
+
+func TestInstrumentResource(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"data": {"id": "abc"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+	m := &providerMeta{Client: client.NewClient("api", "blars", server.URL)}
+
+	r := instrumentResource("lightstep_test", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_name": {Type: schema.TypeString, Required: true},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var resp client.Envelope
+			if err := m.(*providerMeta).Client.CallAPI(ctx, "POST", "projects/tacoman/things", nil, &resp); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("abc")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return diag.Errorf("read failed")
+		},
+	})
+	assert.Nil(t, r.UpdateContext)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_name": "tacoman"})
+	require.False(t, r.CreateContext(context.Background(), d, m).HasError())
+	require.True(t, r.ReadContext(context.Background(), d, m).HasError())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	apiCall, create, read := spans[0], spans[1], spans[2]
+	assert.Equal(t, "CallAPI POST", apiCall.Name)
+	assert.Equal(t, create.SpanContext.SpanID(), apiCall.Parent.SpanID())
+
+	assert.Equal(t, "lightstep_test.create", create.Name)
+	assert.Contains(t, create.Attributes, ResourceTypeKey.String("lightstep_test"))
+	assert.Contains(t, create.Attributes, client.ProjectKey.String("tacoman"))
+	assert.Contains(t, create.Attributes, ResourceIDKey.String("abc"))
+
+	assert.Equal(t, "lightstep_test.read", read.Name)
+	assert.Equal(t, codes.Error, read.Status.Code)
+	assert.Equal(t, "read failed", read.Status.Description)
+}
+
+func TestConfigureTracing_rejects_invalid_endpoint(t *testing.T) {
+	assert.Error(t, configureTracing(context.Background(), "localhost:4318", nil))
+}
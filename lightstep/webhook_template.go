@@ -0,0 +1,264 @@
+package lightstep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// webhookTemplateAlert is the sample alert a webhook template preview is rendered with. Its
+// fields are the provider's own, not taken from a payload reference published by Lightstep.
+type webhookTemplateAlert struct {
+	AlertName   string
+	AlertID     string
+	AlertURL    string
+	ProjectName string
+	// Status is one of warning, critical, resolved or no_data
+	Status      string
+	Description string
+	Value       float64
+	Threshold   float64
+	Labels      map[string]string
+	Timestamp   string
+}
+
+// webhookAlertStatuses are the statuses an alert notification is sent for.
+var webhookAlertStatuses = []string{"warning", "critical", "resolved", "no_data"}
+
+// sampleWebhookAlerts are the alerts rendered by lightstep_webhook_template_preview, by status.
+var sampleWebhookAlerts = map[string]webhookTemplateAlert{
+	"warning":  sampleWebhookAlert("warning", 450),
+	"critical": sampleWebhookAlert("critical", 720),
+	"resolved": sampleWebhookAlert("resolved", 120),
+	"no_data":  sampleWebhookAlert("no_data", 0),
+}
+
+func sampleWebhookAlert(status string, value float64) webhookTemplateAlert {
+	return webhookTemplateAlert{
+		AlertName:   "Checkout latency",
+		AlertID:     "sample-alert",
+		AlertURL:    "https://app.lightstep.com/my-project/alerts/sample-alert",
+		ProjectName: "my-project",
+		Status:      status,
+		Description: "p99 latency of the checkout service",
+		Value:       value,
+		Threshold:   500,
+		Labels:      map[string]string{"team": "checkout", "service": "checkout"},
+		Timestamp:   "2023-01-02T15:04:05Z",
+	}
+}
+
+// webhookTemplateFuncs are stand-ins for the functions Lightstep provides when it renders a
+// template, so previews of templates using them render.
+var webhookTemplateFuncs = template.FuncMap{
+	"toJSON": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// builtinTemplateFuncs are the functions text/template always provides.
+var builtinTemplateFuncs = map[string]bool{
+	"and": true, "call": true, "html": true, "index": true, "slice": true, "js": true, "len": true,
+	"not": true, "or": true, "print": true, "printf": true, "println": true, "urlquery": true,
+	"eq": true, "ge": true, "gt": true, "le": true, "lt": true, "ne": true,
+}
+
+// validateWebhookTemplate checks the syntax of a webhook template. Functions aren't checked,
+// since they are provided by Lightstep when the template is rendered.
+func validateWebhookTemplate(i interface{}, path cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type to be string")
+	}
+
+	tree := parse.New("template")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(v, "", "", map[string]*parse.Tree{}); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid webhook template",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// webhookTemplatePreview is a webhook template parsed for a preview.
+type webhookTemplatePreview struct {
+	*template.Template
+	// unknownFuncs are the functions called that are neither built in nor in
+	// webhookTemplateFuncs. They render as a "<name>" placeholder.
+	unknownFuncs []string
+	// unknownFields are the fields of the alert referenced that webhookTemplateAlert doesn't
+	// have. They render as a "<.Name>" placeholder.
+	unknownFields []string
+}
+
+// parseWebhookTemplate parses a webhook template for a preview.
+func parseWebhookTemplate(text string) (*webhookTemplatePreview, error) {
+	tree := parse.New("template")
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{}
+	for name, fn := range webhookTemplateFuncs {
+		funcs[name] = fn
+	}
+	known := webhookTemplateAlert{}.fields()
+	p := &webhookTemplatePreview{}
+	seen := map[string]bool{}
+	for _, t := range trees {
+		for _, name := range templateFuncNames(t.Root) {
+			if _, ok := funcs[name]; ok || builtinTemplateFuncs[name] {
+				continue
+			}
+			placeholder := fmt.Sprintf("<%s>", name)
+			funcs[name] = func(...interface{}) string { return placeholder }
+			p.unknownFuncs = append(p.unknownFuncs, name)
+		}
+		for _, name := range templateFieldNames(t.Root, true) {
+			if _, ok := known[name]; ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			p.unknownFields = append(p.unknownFields, name)
+		}
+	}
+	sort.Strings(p.unknownFuncs)
+	sort.Strings(p.unknownFields)
+
+	t, err := template.New("template").Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	p.Template = t
+	return p, nil
+}
+
+// fields returns the fields of the alert by name, the data a template is executed with.
+func (a webhookTemplateAlert) fields() map[string]interface{} {
+	v := reflect.ValueOf(a)
+	fields := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Name] = v.Field(i).Interface()
+	}
+	return fields
+}
+
+// templateFieldNames returns the names of the alert fields referenced under node. Fields are
+// only taken from "." while it is the alert (atAlert), and from "$" anywhere.
+func templateFieldNames(node parse.Node, atAlert bool) []string {
+	var names []string
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		for _, n := range node.Nodes {
+			names = append(names, templateFieldNames(n, atAlert)...)
+		}
+	case *parse.ActionNode:
+		names = templateFieldNames(node.Pipe, atAlert)
+	case *parse.IfNode:
+		names = append(templateFieldNames(node.Pipe, atAlert), templateFieldNames(node.List, atAlert)...)
+		names = append(names, templateFieldNames(node.ElseList, atAlert)...)
+	case *parse.RangeNode:
+		// "." is the element within the body
+		names = append(templateFieldNames(node.Pipe, atAlert), templateFieldNames(node.List, false)...)
+		names = append(names, templateFieldNames(node.ElseList, atAlert)...)
+	case *parse.WithNode:
+		names = append(templateFieldNames(node.Pipe, atAlert), templateFieldNames(node.List, false)...)
+		names = append(names, templateFieldNames(node.ElseList, atAlert)...)
+	case *parse.TemplateNode:
+		names = templateFieldNames(node.Pipe, atAlert)
+	case *parse.PipeNode:
+		if node == nil {
+			return nil
+		}
+		for _, cmd := range node.Cmds {
+			names = append(names, templateFieldNames(cmd, atAlert)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			names = append(names, templateFieldNames(arg, atAlert)...)
+		}
+	case *parse.FieldNode:
+		if atAlert {
+			names = []string{node.Ident[0]}
+		}
+	case *parse.VariableNode:
+		if len(node.Ident) > 1 && node.Ident[0] == "$" {
+			names = []string{node.Ident[1]}
+		}
+	}
+	return names
+}
+
+// templateFuncNames returns the names of the functions called under node.
+func templateFuncNames(node parse.Node) []string {
+	var names []string
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		for _, n := range node.Nodes {
+			names = append(names, templateFuncNames(n)...)
+		}
+	case *parse.ActionNode:
+		names = templateFuncNames(node.Pipe)
+	case *parse.IfNode:
+		names = templateFuncNames(&node.BranchNode)
+	case *parse.RangeNode:
+		names = templateFuncNames(&node.BranchNode)
+	case *parse.WithNode:
+		names = templateFuncNames(&node.BranchNode)
+	case *parse.BranchNode:
+		names = append(templateFuncNames(node.Pipe), templateFuncNames(node.List)...)
+		names = append(names, templateFuncNames(node.ElseList)...)
+	case *parse.TemplateNode:
+		names = templateFuncNames(node.Pipe)
+	case *parse.PipeNode:
+		if node == nil {
+			return nil
+		}
+		for _, cmd := range node.Cmds {
+			names = append(names, templateFuncNames(cmd)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			names = append(names, templateFuncNames(arg)...)
+		}
+	case *parse.ChainNode:
+		names = templateFuncNames(node.Node)
+	case *parse.IdentifierNode:
+		names = []string{node.Ident}
+	}
+	return names
+}
+
+// renderWebhookTemplate renders a parsed webhook template with alert.
+func renderWebhookTemplate(t *webhookTemplatePreview, alert webhookTemplateAlert) (string, error) {
+	data := alert.fields()
+	for _, name := range t.unknownFields {
+		data[name] = fmt.Sprintf("<.%s>", name)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template for a %s alert: %v", alert.Status, err)
+	}
+	return buf.String(), nil
+}
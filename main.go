@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/lightstep/terraform-provider-lightstep/exporter"
 	"github.com/lightstep/terraform-provider-lightstep/lightstep"
-	"log"
-	"os"
 )
 
 func main() {
@@ -22,4 +24,8 @@ func main() {
 			return lightstep.Provider()
 		},
 	})
+
+	if err := lightstep.ShutdownTracing(context.Background()); err != nil {
+		log.Printf("[WARN] failed to flush traces: %s", err.Error())
+	}
 }